@@ -0,0 +1,193 @@
+// Package backup exports the sources, destinations and connections of a workspace to a versioned bundle, and imports
+// such bundles into a workspace.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/redact"
+)
+
+// Version is the version of the bundles written by this package. Bundles of other versions cannot be read.
+const Version = 1
+
+// Bundle is a snapshot of the sources, destinations and connections of a workspace.
+type Bundle struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exportedAt"`
+	// SecretsStripped reports whether secrets, such as passwords and write keys, were left out of the bundle.
+	SecretsStripped bool `json:"secretsStripped"`
+
+	Sources      []client.Source      `json:"sources"`
+	Destinations []client.Destination `json:"destinations"`
+	Connections  []client.Connection  `json:"connections"`
+}
+
+// Write writes the bundle as indented JSON.
+func (b *Bundle) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b)
+}
+
+// Read reads a bundle written by Bundle.Write.
+func Read(r io.Reader) (*Bundle, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{}
+	if err := json.Unmarshal(data, bundle); err != nil {
+		return nil, fmt.Errorf("could not parse bundle: %w", err)
+	}
+
+	if bundle.Version != Version {
+		return nil, fmt.Errorf("unsupported bundle version %d, expected %d", bundle.Version, Version)
+	}
+
+	return bundle, nil
+}
+
+type exportOptions struct {
+	keepSecrets bool
+}
+
+type ExportOption func(*exportOptions)
+
+// WithSecrets keeps secrets in the exported bundle. The bundle must then be stored as securely as the workspace
+// credentials themselves.
+func WithSecrets() ExportOption {
+	return func(o *exportOptions) {
+		o.keepSecrets = true
+	}
+}
+
+// Export walks every page of the sources, destinations and connections of the workspace into a bundle. Secrets are
+// stripped from the bundle unless WithSecrets is given: the fields of configs whose name denotes a secret, e.g.
+// "password" or "apiKey", and the write keys of sources.
+func Export(ctx context.Context, c *client.Client, options ...ExportOption) (*Bundle, error) {
+	o := &exportOptions{}
+	for _, option := range options {
+		option(o)
+	}
+
+	bundle := &Bundle{
+		Version:         Version,
+		ExportedAt:      time.Now().UTC(),
+		SecretsStripped: !o.keepSecrets,
+	}
+
+	sources := c.Sources.All(ctx)
+	for sources.Next() {
+		source := sources.Source()
+		if !o.keepSecrets {
+			source.WriteKey = ""
+			source.Config = redact.StripJSON(source.Config)
+		}
+		bundle.Sources = append(bundle.Sources, source)
+	}
+	if err := sources.Err(); err != nil {
+		return nil, err
+	}
+
+	destinations := c.Destinations.All(ctx)
+	for destinations.Next() {
+		destination := destinations.Destination()
+		if !o.keepSecrets {
+			destination.Config = redact.StripJSON(destination.Config)
+		}
+		bundle.Destinations = append(bundle.Destinations, destination)
+	}
+	if err := destinations.Err(); err != nil {
+		return nil, err
+	}
+
+	connections := c.Connections.All(ctx)
+	for connections.Next() {
+		bundle.Connections = append(bundle.Connections, connections.Connection())
+	}
+	if err := connections.Err(); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// ImportResult maps the IDs of the resources of a bundle to the IDs of the resources created by Import.
+type ImportResult struct {
+	SourceIDs      map[string]string
+	DestinationIDs map[string]string
+	ConnectionIDs  map[string]string
+}
+
+// ImportError is returned by Import when a resource cannot be created. Resources preceding it have been created.
+type ImportError struct {
+	// Kind is "source", "destination" or "connection", and ID is the ID of the resource in the bundle.
+	Kind string
+	ID   string
+	Err  error
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("could not import %s '%s': %v", e.Kind, e.ID, e.Err)
+}
+
+func (e *ImportError) Unwrap() error {
+	return e.Err
+}
+
+// Import recreates the sources, destinations and connections of a bundle in the workspace of the client, as new
+// resources. Connections are remapped to the IDs of the created sources and destinations. Import stops at the first
+// error, returning an *ImportError along with the result so far. Resources of a bundle whose secrets were stripped
+// are created without them, and might have to be completed afterwards.
+func Import(ctx context.Context, c *client.Client, bundle *Bundle) (*ImportResult, error) {
+	result := &ImportResult{
+		SourceIDs:      map[string]string{},
+		DestinationIDs: map[string]string{},
+		ConnectionIDs:  map[string]string{},
+	}
+
+	for i := range bundle.Sources {
+		source, err := c.Sources.Create(ctx, &bundle.Sources[i])
+		if err != nil {
+			return result, &ImportError{Kind: "source", ID: bundle.Sources[i].ID, Err: err}
+		}
+		result.SourceIDs[bundle.Sources[i].ID] = source.ID
+	}
+
+	for i := range bundle.Destinations {
+		destination, err := c.Destinations.Create(ctx, &bundle.Destinations[i])
+		if err != nil {
+			return result, &ImportError{Kind: "destination", ID: bundle.Destinations[i].ID, Err: err}
+		}
+		result.DestinationIDs[bundle.Destinations[i].ID] = destination.ID
+	}
+
+	for _, connection := range bundle.Connections {
+		id := connection.ID
+		sourceID, ok := result.SourceIDs[connection.SourceID]
+		if !ok {
+			return result, &ImportError{Kind: "connection", ID: id, Err: fmt.Errorf("source '%s' is not part of the bundle", connection.SourceID)}
+		}
+		destinationID, ok := result.DestinationIDs[connection.DestinationID]
+		if !ok {
+			return result, &ImportError{Kind: "connection", ID: id, Err: fmt.Errorf("destination '%s' is not part of the bundle", connection.DestinationID)}
+		}
+
+		connection.SourceID, connection.DestinationID = sourceID, destinationID
+		created, err := c.Connections.Create(ctx, &connection)
+		if err != nil {
+			return result, &ImportError{Kind: "connection", ID: id, Err: err}
+		}
+		result.ConnectionIDs[id] = created.ID
+	}
+
+	return result, nil
+}
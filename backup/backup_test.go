@@ -0,0 +1,121 @@
+package backup_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/backup"
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/rudderapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seed(t *testing.T, ctx context.Context, c *client.Client) {
+	source, err := c.Sources.Create(ctx, &client.Source{Name: "web", Type: "Javascript", IsEnabled: true, Config: json.RawMessage(`{}`)})
+	require.NoError(t, err)
+
+	destination, err := c.Destinations.Create(ctx, &client.Destination{
+		Name:      "warehouse",
+		Type:      "POSTGRES",
+		IsEnabled: true,
+		Config:    json.RawMessage(`{"host": "db.example.com", "password": "secret"}`),
+	})
+	require.NoError(t, err)
+
+	_, err = c.Connections.Create(ctx, &client.Connection{SourceID: source.ID, DestinationID: destination.ID, IsEnabled: true})
+	require.NoError(t, err)
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+
+	from := rudderapitest.NewServer(rudderapitest.WithPageSize(1))
+	defer from.Close()
+	fromClient, err := from.Client()
+	require.NoError(t, err)
+	seed(t, ctx, fromClient)
+
+	bundle, err := backup.Export(ctx, fromClient)
+	require.NoError(t, err)
+	assert.Equal(t, backup.Version, bundle.Version)
+	assert.True(t, bundle.SecretsStripped)
+	require.Len(t, bundle.Sources, 1)
+	assert.Empty(t, bundle.Sources[0].WriteKey)
+	require.Len(t, bundle.Destinations, 1)
+	assert.JSONEq(t, `{"host": "db.example.com"}`, string(bundle.Destinations[0].Config))
+	require.Len(t, bundle.Connections, 1)
+
+	var buf bytes.Buffer
+	require.NoError(t, bundle.Write(&buf))
+	read, err := backup.Read(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, bundle.Connections, read.Connections)
+
+	to := rudderapitest.NewServer()
+	defer to.Close()
+	toClient, err := to.Client()
+	require.NoError(t, err)
+
+	result, err := backup.Import(ctx, toClient, read)
+	require.NoError(t, err)
+
+	connections, err := toClient.Connections.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, connections.Connections, 1)
+	connection := connections.Connections[0]
+	assert.Equal(t, result.SourceIDs[bundle.Sources[0].ID], connection.SourceID)
+	assert.Equal(t, result.DestinationIDs[bundle.Destinations[0].ID], connection.DestinationID)
+	assert.Equal(t, result.ConnectionIDs[bundle.Connections[0].ID], connection.ID)
+
+	destination, err := toClient.Destinations.Get(ctx, connection.DestinationID)
+	require.NoError(t, err)
+	assert.Equal(t, "warehouse", destination.Name)
+	assert.JSONEq(t, `{"host": "db.example.com"}`, string(destination.Config))
+}
+
+func TestExportWithSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	server := rudderapitest.NewServer()
+	defer server.Close()
+	c, err := server.Client()
+	require.NoError(t, err)
+	seed(t, ctx, c)
+
+	bundle, err := backup.Export(ctx, c, backup.WithSecrets())
+	require.NoError(t, err)
+	assert.False(t, bundle.SecretsStripped)
+	assert.NotEmpty(t, bundle.Sources[0].WriteKey)
+	assert.JSONEq(t, `{"host": "db.example.com", "password": "secret"}`, string(bundle.Destinations[0].Config))
+}
+
+func TestImportUnknownSource(t *testing.T) {
+	ctx := context.Background()
+
+	server := rudderapitest.NewServer()
+	defer server.Close()
+	c, err := server.Client()
+	require.NoError(t, err)
+
+	bundle := &backup.Bundle{
+		Version:      backup.Version,
+		Destinations: []client.Destination{{ID: "destination-1", Name: "warehouse", Type: "POSTGRES", Config: json.RawMessage(`{}`)}},
+		Connections:  []client.Connection{{ID: "connection-1", SourceID: "source-1", DestinationID: "destination-1"}},
+	}
+
+	result, err := backup.Import(ctx, c, bundle)
+	var importErr *backup.ImportError
+	require.ErrorAs(t, err, &importErr)
+	assert.Equal(t, "connection", importErr.Kind)
+	assert.EqualError(t, err, "could not import connection 'connection-1': source 'source-1' is not part of the bundle")
+	assert.Len(t, result.DestinationIDs, 1)
+}
+
+func TestReadUnsupportedVersion(t *testing.T) {
+	_, err := backup.Read(strings.NewReader(`{"version": 2}`))
+	assert.EqualError(t, err, "unsupported bundle version 2, expected 1")
+}
@@ -0,0 +1,191 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"time"
+)
+
+// AuditLog is an entry of the audit log of a workspace, recording an action of an actor on a resource.
+type AuditLog struct {
+	ID           string          `json:"id"`
+	Timestamp    time.Time       `json:"timestamp"`
+	ActorID      string          `json:"actorId"`
+	ActorEmail   string          `json:"actorEmail,omitempty"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resourceType"`
+	ResourceID   string          `json:"resourceId,omitempty"`
+	Details      json.RawMessage `json:"details,omitempty"`
+}
+
+// AuditLogFilter filters the audit log entries. Zero fields match all entries.
+type AuditLogFilter struct {
+	// From and To bound the timestamps of the entries, From being inclusive and To exclusive.
+	From time.Time
+	To   time.Time
+
+	ActorID      string
+	ResourceType string
+	Action       string
+}
+
+func (f AuditLogFilter) query() url.Values {
+	query := url.Values{}
+	if !f.From.IsZero() {
+		query.Set("from", f.From.UTC().Format(time.RFC3339Nano))
+	}
+	if !f.To.IsZero() {
+		query.Set("to", f.To.UTC().Format(time.RFC3339Nano))
+	}
+	if f.ActorID != "" {
+		query.Set("actorId", f.ActorID)
+	}
+	if f.ResourceType != "" {
+		query.Set("resourceType", f.ResourceType)
+	}
+	if f.Action != "" {
+		query.Set("action", f.Action)
+	}
+	return query
+}
+
+type auditLogs struct {
+	*service
+}
+
+type AuditLogsPage struct {
+	APIPage
+	AuditLogs []AuditLog `json:"auditLogs"`
+}
+
+func (s *auditLogs) path(query url.Values) string {
+	if len(query) == 0 {
+		return s.basePath
+	}
+	return s.basePath + "?" + query.Encode()
+}
+
+func (s *auditLogs) Next(ctx context.Context, paging Paging) (*AuditLogsPage, error) {
+	page := &AuditLogsPage{}
+	ok, err := s.service.next(ctx, paging, page)
+	if !ok {
+		page = nil
+	}
+	return page, err
+}
+
+// List returns the first page of the audit log entries matching filter.
+func (s *auditLogs) List(ctx context.Context, filter AuditLogFilter) (*AuditLogsPage, error) {
+	page := &AuditLogsPage{}
+	if _, err := s.service.next(ctx, Paging{Next: s.path(filter.query())}, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// AuditLogsIterator iterates over audit log entries, fetching pages on demand.
+type AuditLogsIterator struct {
+	pager
+	auditLogs []AuditLog
+	index     int
+}
+
+// All returns an iterator over all the audit log entries matching filter. Pages are fetched lazily, as the iterator
+// advances. Any error, including the cancellation of ctx, stops the iteration and is available through Err.
+func (s *auditLogs) All(ctx context.Context, filter AuditLogFilter) *AuditLogsIterator {
+	return &AuditLogsIterator{pager: s.pagerFrom(ctx, s.path(filter.query()))}
+}
+
+// Next advances the iterator to the next entry. It returns false when there are no more entries or an error occurred.
+func (it *AuditLogsIterator) Next() bool {
+	if it.stopped() {
+		return false
+	}
+
+	for it.index >= len(it.auditLogs) {
+		page := &AuditLogsPage{}
+		if !it.fetch(page, &page.APIPage) {
+			return false
+		}
+		it.auditLogs, it.index = page.AuditLogs, 0
+	}
+
+	it.index++
+	return true
+}
+
+// AuditLog returns the current entry.
+func (it *AuditLogsIterator) AuditLog() AuditLog {
+	return it.auditLogs[it.index-1]
+}
+
+// AuditLogCursor is the position of an export in the audit log. It can be stored as JSON between runs.
+type AuditLogCursor struct {
+	// Timestamp is the timestamp of the last exported entry.
+	Timestamp time.Time `json:"timestamp"`
+	// IDs are the IDs of the exported entries sharing Timestamp, which are skipped when resuming the export.
+	IDs []string `json:"ids,omitempty"`
+}
+
+func (c *AuditLogCursor) exported(entry AuditLog) bool {
+	if entry.Timestamp.Before(c.Timestamp) {
+		return true
+	}
+	if entry.Timestamp.Equal(c.Timestamp) {
+		for _, id := range c.IDs {
+			if id == entry.ID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// advance moves the cursor to cover entry, which might be older than the entries covered already if the API does not
+// return them in order.
+func (c *AuditLogCursor) advance(entry AuditLog) {
+	if entry.Timestamp.Before(c.Timestamp) {
+		return
+	}
+	if entry.Timestamp.After(c.Timestamp) {
+		c.Timestamp, c.IDs = entry.Timestamp, nil
+	}
+	c.IDs = append(c.IDs, entry.ID)
+}
+
+// Export writes the audit log entries matching filter to w as newline delimited JSON, oldest first. If cursor is not
+// nil, the export resumes after the entries it covers. The returned cursor covers all the entries written to w, and is
+// returned even if an error interrupts the export, so that the next export picks up where this one stopped.
+func (s *auditLogs) Export(ctx context.Context, w io.Writer, filter AuditLogFilter, cursor *AuditLogCursor) (*AuditLogCursor, error) {
+	next := &AuditLogCursor{}
+	if cursor != nil {
+		next.Timestamp = cursor.Timestamp
+		next.IDs = append(next.IDs, cursor.IDs...)
+		if filter.From.Before(cursor.Timestamp) {
+			filter.From = cursor.Timestamp
+		}
+	}
+
+	query := filter.query()
+	query.Set("order", "asc")
+
+	// entries are only skipped against the cursor of the caller, as the entries of this export might come in any order
+	encoder := json.NewEncoder(w)
+	it := &AuditLogsIterator{pager: s.pagerFrom(ctx, s.path(query))}
+	for it.Next() {
+		entry := it.AuditLog()
+		if cursor != nil && cursor.exported(entry) {
+			continue
+		}
+
+		if err := encoder.Encode(entry); err != nil {
+			return next, err
+		}
+		next.advance(entry)
+	}
+
+	return next, it.Err()
+}
@@ -0,0 +1,214 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientAuditLogsList(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/auditLogs?action=deleted&actorId=user-1&from=2020-01-01T00%3A00%3A00Z&resourceType=source&to=2020-02-01T00%3A00%3A00Z", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"auditLogs": [{
+					"id": "id-1",
+					"timestamp": "2020-01-02T01:01:01Z",
+					"actorId": "user-1",
+					"action": "deleted",
+					"resourceType": "source",
+					"resourceId": "source-1"
+				}],
+				"paging": { "total": 2, "next": "/auditLogs?page=2" }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/auditLogs?page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"auditLogs": [{ "id": "id-2", "timestamp": "2020-01-03T01:01:01Z" }],
+				"paging": { "total": 2 }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/auditLogs", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"auditLogs": [{ "id": "id-1" }, { "id": "id-2" }],
+				"paging": { "total": 2 }
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	page, err := c.AuditLogs.List(ctx, client.AuditLogFilter{
+		From:         time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:           time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+		ActorID:      "user-1",
+		ResourceType: "source",
+		Action:       "deleted",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []client.AuditLog{{
+		ID:           "id-1",
+		Timestamp:    time.Date(2020, 1, 2, 1, 1, 1, 0, time.UTC),
+		ActorID:      "user-1",
+		Action:       "deleted",
+		ResourceType: "source",
+		ResourceID:   "source-1",
+	}}, page.AuditLogs)
+
+	page, err = c.AuditLogs.Next(ctx, page.Paging)
+	require.NoError(t, err)
+	require.Len(t, page.AuditLogs, 1)
+	assert.Equal(t, "id-2", page.AuditLogs[0].ID)
+
+	var ids []string
+	it := c.AuditLogs.All(ctx, client.AuditLogFilter{})
+	for it.Next() {
+		ids = append(ids, it.AuditLog().ID)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"id-1", "id-2"}, ids)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientAuditLogsExport(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/auditLogs?order=asc&resourceType=source", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"auditLogs": [
+					{ "id": "id-1", "timestamp": "2020-01-01T00:00:00Z", "action": "created", "resourceType": "source" },
+					{ "id": "id-2", "timestamp": "2020-01-02T00:00:00Z", "action": "updated", "resourceType": "source" }
+				],
+				"paging": { "total": 3, "next": "/auditLogs?order=asc&resourceType=source&page=2" }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/auditLogs?order=asc&resourceType=source&page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"auditLogs": [
+					{ "id": "id-3", "timestamp": "2020-01-02T00:00:00Z", "action": "updated", "resourceType": "source" }
+				],
+				"paging": { "total": 3 }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/auditLogs?from=2020-01-02T00%3A00%3A00Z&order=asc&resourceType=source", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"auditLogs": [
+					{ "id": "id-2", "timestamp": "2020-01-02T00:00:00Z", "action": "updated", "resourceType": "source" },
+					{ "id": "id-3", "timestamp": "2020-01-02T00:00:00Z", "action": "updated", "resourceType": "source" },
+					{ "id": "id-4", "timestamp": "2020-01-03T00:00:00Z", "action": "deleted", "resourceType": "source" }
+				],
+				"paging": { "total": 3, "next": "/auditLogs?from=2020-01-02T00%3A00%3A00Z&order=asc&resourceType=source&page=2" }
+			}`,
+		},
+		{
+			ResponseStatus: 500,
+			ResponseBody:   `{ "error": "some error", "code": "some-code" }`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	filter := client.AuditLogFilter{ResourceType: "source"}
+
+	var out bytes.Buffer
+	cursor, err := c.AuditLogs.Export(ctx, &out, filter, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"id-1","timestamp":"2020-01-01T00:00:00Z","actorId":"","action":"created","resourceType":"source"}
+{"id":"id-2","timestamp":"2020-01-02T00:00:00Z","actorId":"","action":"updated","resourceType":"source"}
+{"id":"id-3","timestamp":"2020-01-02T00:00:00Z","actorId":"","action":"updated","resourceType":"source"}
+`, out.String())
+	assert.Equal(t, &client.AuditLogCursor{Timestamp: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), IDs: []string{"id-2", "id-3"}}, cursor)
+
+	// the second export resumes after the entries of the first one, and returns a cursor covering
+	// what was written even though it fails
+	out.Reset()
+	cursor, err = c.AuditLogs.Export(ctx, &out, filter, cursor)
+	assert.Error(t, err)
+	assert.Equal(t, `{"id":"id-4","timestamp":"2020-01-03T00:00:00Z","actorId":"","action":"deleted","resourceType":"source"}
+`, out.String())
+	assert.Equal(t, &client.AuditLogCursor{Timestamp: time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC), IDs: []string{"id-4"}}, cursor)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientAuditLogsExportDescending(t *testing.T) {
+	ctx := context.Background()
+
+	// the API ignores order=asc and returns the newest entries first
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/auditLogs?from=2020-01-01T00%3A00%3A00Z&order=asc", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"auditLogs": [
+					{ "id": "id-4", "timestamp": "2020-01-03T00:00:00Z", "action": "deleted", "resourceType": "source" },
+					{ "id": "id-3", "timestamp": "2020-01-02T00:00:00Z", "action": "updated", "resourceType": "source" },
+					{ "id": "id-2", "timestamp": "2020-01-01T00:00:00Z", "action": "updated", "resourceType": "source" },
+					{ "id": "id-1", "timestamp": "2020-01-01T00:00:00Z", "action": "created", "resourceType": "source" }
+				],
+				"paging": { "total": 4 }
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	cursor, err := c.AuditLogs.Export(ctx, &out, client.AuditLogFilter{}, &client.AuditLogCursor{
+		Timestamp: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		IDs:       []string{"id-1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"id-4","timestamp":"2020-01-03T00:00:00Z","actorId":"","action":"deleted","resourceType":"source"}
+{"id":"id-3","timestamp":"2020-01-02T00:00:00Z","actorId":"","action":"updated","resourceType":"source"}
+{"id":"id-2","timestamp":"2020-01-01T00:00:00Z","actorId":"","action":"updated","resourceType":"source"}
+`, out.String())
+	assert.Equal(t, &client.AuditLogCursor{Timestamp: time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC), IDs: []string{"id-4"}}, cursor)
+
+	httpClient.AssertNumberOfCalls()
+}
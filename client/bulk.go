@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultBulkConcurrency is the number of concurrent requests of bulk operations, unless set otherwise.
+const DefaultBulkConcurrency = 4
+
+// BulkResult reports the outcome of a bulk operation, with an item per matching resource, in listing order.
+// The failure of an item does not prevent the other ones from being processed.
+type BulkResult struct {
+	Items []BulkItem
+}
+
+// BulkItem is the outcome of a bulk operation for a single resource.
+type BulkItem struct {
+	ID string
+	// Changed is false if the resource was already in the requested state, or if it could not be changed.
+	Changed bool
+	Err     error
+}
+
+// Failed returns the items which could not be processed.
+func (r *BulkResult) Failed() []BulkItem {
+	var failed []BulkItem
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// Err returns an error summarising the failed items, or nil if all of them succeeded.
+func (r *BulkResult) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d items failed, first error: '%s': %w", len(failed), len(r.Items), failed[0].ID, failed[0].Err)
+}
+
+// bulkTarget is a resource matched by a bulk operation, which is skipped if already in the requested state.
+type bulkTarget struct {
+	id   string
+	skip bool
+}
+
+// runBulk applies apply to the targets which are not skipped, with at most concurrency calls at a time. A concurrency
+// lower than 1 defaults to DefaultBulkConcurrency. Once ctx is done, the remaining targets fail with its error.
+func runBulk(ctx context.Context, targets []bulkTarget, concurrency int, apply func(ctx context.Context, id string) error) *BulkResult {
+	if concurrency < 1 {
+		concurrency = DefaultBulkConcurrency
+	}
+
+	result := &BulkResult{Items: make([]BulkItem, len(targets))}
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, target := range targets {
+		item := &result.Items[i]
+		item.ID = target.id
+		if target.skip {
+			continue
+		}
+
+		if err := acquire(ctx, semaphore); err != nil {
+			item.Err = err
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			item.Err = apply(ctx, item.ID)
+			item.Changed = item.Err == nil
+		}()
+	}
+
+	wg.Wait()
+	return result
+}
+
+func acquire(ctx context.Context, semaphore chan struct{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case semaphore <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
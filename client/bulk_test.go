@@ -0,0 +1,227 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/rudderapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingHTTPClient fails the PUT requests of the given path, and sends the other ones.
+type failingHTTPClient struct {
+	path string
+}
+
+func (c failingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method == "PUT" && strings.HasSuffix(req.URL.Path, c.path) {
+		return &http.Response{
+			StatusCode: 500,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"error": "internal error", "code": "internal_error"}`)),
+		}, nil
+	}
+	return http.DefaultClient.Do(req)
+}
+
+// blockingHTTPClient holds PUT requests until limit of them are in flight, or for 200ms, recording the highest
+// number of requests in flight at once.
+type blockingHTTPClient struct {
+	limit int
+
+	mu       sync.Mutex
+	cond     *sync.Cond
+	inFlight int
+	max      int
+}
+
+func newBlockingHTTPClient(limit int) *blockingHTTPClient {
+	c := &blockingHTTPClient{limit: limit}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *blockingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != "PUT" {
+		return http.DefaultClient.Do(req)
+	}
+
+	c.mu.Lock()
+	c.inFlight++
+	if c.inFlight > c.max {
+		c.max = c.inFlight
+	}
+	c.cond.Broadcast()
+
+	timeout := time.AfterFunc(200*time.Millisecond, c.cond.Broadcast)
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for c.inFlight < c.limit && time.Now().Before(deadline) {
+		c.cond.Wait()
+	}
+	timeout.Stop()
+	c.mu.Unlock()
+
+	res, err := http.DefaultClient.Do(req)
+
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+	return res, err
+}
+
+func TestClientEnableDisable(t *testing.T) {
+	ctx := context.Background()
+	server := rudderapitest.NewServer()
+	defer server.Close()
+
+	c, err := server.Client()
+	require.NoError(t, err)
+
+	destination, err := c.Destinations.Create(ctx, &client.Destination{Name: "some-name", Type: "WEBHOOK", IsEnabled: true})
+	require.NoError(t, err)
+
+	disabled, err := c.Destinations.Disable(ctx, destination.ID)
+	require.NoError(t, err)
+	assert.False(t, disabled.IsEnabled)
+	assert.Equal(t, "some-name", disabled.Name)
+
+	enabled, err := c.Destinations.Enable(ctx, destination.ID)
+	require.NoError(t, err)
+	assert.True(t, enabled.IsEnabled)
+}
+
+func TestClientDisableMatching(t *testing.T) {
+	ctx := context.Background()
+	server := rudderapitest.NewServer(rudderapitest.WithPageSize(2))
+	defer server.Close()
+
+	c, err := server.Client()
+	require.NoError(t, err)
+
+	var ids []string
+	for _, d := range []client.Destination{
+		{Name: "webhook-1", Type: "WEBHOOK", IsEnabled: true},
+		{Name: "postgres", Type: "POSTGRES", IsEnabled: true},
+		{Name: "webhook-2", Type: "WEBHOOK", IsEnabled: false},
+		{Name: "webhook-3", Type: "WEBHOOK", IsEnabled: true},
+	} {
+		d := d
+		created, err := c.Destinations.Create(ctx, &d)
+		require.NoError(t, err)
+		ids = append(ids, created.ID)
+	}
+
+	result, err := c.Destinations.DisableMatching(ctx, client.DestinationFilter{Type: "WEBHOOK"}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []client.BulkItem{
+		{ID: ids[0], Changed: true},
+		{ID: ids[2], Changed: false},
+		{ID: ids[3], Changed: true},
+	}, result.Items)
+	assert.NoError(t, result.Err())
+
+	for i, enabled := range []bool{false, true, false, false} {
+		d, err := c.Destinations.Get(ctx, ids[i])
+		require.NoError(t, err)
+		assert.Equal(t, enabled, d.IsEnabled, d.Name)
+	}
+}
+
+func TestClientEnableMatchingConnections(t *testing.T) {
+	ctx := context.Background()
+	server := rudderapitest.NewServer()
+	defer server.Close()
+
+	setup, err := server.Client()
+	require.NoError(t, err)
+
+	source, err := setup.Sources.Create(ctx, &client.Source{Name: "some-source", Type: "HTTP"})
+	require.NoError(t, err)
+	other, err := setup.Sources.Create(ctx, &client.Source{Name: "other-source", Type: "HTTP"})
+	require.NoError(t, err)
+
+	var connections []*client.Connection
+	for _, name := range []string{"destination-1", "destination-2"} {
+		destination, err := setup.Destinations.Create(ctx, &client.Destination{Name: name, Type: "WEBHOOK", IsEnabled: true})
+		require.NoError(t, err)
+
+		for _, s := range []*client.Source{source, other} {
+			connection, err := setup.Connections.Create(ctx, &client.Connection{SourceID: s.ID, DestinationID: destination.ID})
+			require.NoError(t, err)
+			connections = append(connections, connection)
+		}
+	}
+
+	// the first connection of the source cannot be updated
+	c, err := server.Client(client.WithHTTPClient(failingHTTPClient{path: "/connections/" + connections[0].ID}))
+	require.NoError(t, err)
+
+	result, err := c.Connections.EnableMatching(ctx, client.ConnectionFilter{SourceID: source.ID}, 0)
+	require.NoError(t, err)
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, connections[0].ID, result.Items[0].ID)
+	assert.False(t, result.Items[0].Changed)
+	var apiErr *client.APIError
+	require.True(t, errors.As(result.Items[0].Err, &apiErr))
+	assert.Equal(t, 500, apiErr.HTTPStatusCode)
+	assert.Equal(t, client.BulkItem{ID: connections[2].ID, Changed: true}, result.Items[1])
+	assert.Equal(t, result.Items[:1], result.Failed())
+	assert.Error(t, result.Err())
+
+	for i, enabled := range []bool{false, false, true, false} {
+		connection, err := c.Connections.Get(ctx, connections[i].ID)
+		require.NoError(t, err)
+		assert.Equal(t, enabled, connection.IsEnabled)
+	}
+}
+
+func TestClientEnableMatchingConcurrency(t *testing.T) {
+	ctx := context.Background()
+	server := rudderapitest.NewServer()
+	defer server.Close()
+
+	setup, err := server.Client()
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, err := setup.Sources.Create(ctx, &client.Source{Name: fmt.Sprintf("source-%d", i), Type: "HTTP"})
+		require.NoError(t, err)
+	}
+
+	const concurrency = 3
+	httpClient := newBlockingHTTPClient(concurrency)
+	c, err := server.Client(client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	result, err := c.Sources.EnableMatching(ctx, client.SourceFilter{}, concurrency)
+	require.NoError(t, err)
+	require.NoError(t, result.Err())
+	assert.Len(t, result.Items, 10)
+
+	// requests wait for each other until the limit is reached, so it is reached exactly
+	assert.Equal(t, concurrency, httpClient.max)
+}
+
+func TestClientEnableMatchingCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	server := rudderapitest.NewServer()
+	defer server.Close()
+
+	c, err := server.Client()
+	require.NoError(t, err)
+
+	_, err = c.Sources.Create(ctx, &client.Source{Name: "some-source", Type: "HTTP"})
+	require.NoError(t, err)
+
+	cancel()
+	_, err = c.Sources.EnableMatching(ctx, client.SourceFilter{}, 1)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
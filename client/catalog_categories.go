@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// CatalogCategory groups events of the data catalog.
+type CatalogCategory struct {
+	ID        string     `json:"id,omitempty"`
+	Name      string     `json:"name"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+type catalogCategories struct {
+	*service
+}
+
+type CatalogCategoriesPage struct {
+	APIPage
+	Categories []CatalogCategory `json:"categories"`
+}
+
+func (s *catalogCategories) Next(ctx context.Context, paging Paging) (*CatalogCategoriesPage, error) {
+	page := &CatalogCategoriesPage{}
+	ok, err := s.service.next(ctx, paging, page)
+	if !ok {
+		page = nil
+	}
+	return page, err
+}
+
+func (s *catalogCategories) List(ctx context.Context) (*CatalogCategoriesPage, error) {
+	page := &CatalogCategoriesPage{}
+	if err := s.list(ctx, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// CatalogCategoriesIterator iterates over all catalog categories, fetching pages on demand.
+type CatalogCategoriesIterator struct {
+	pager
+	categories []CatalogCategory
+	index      int
+}
+
+// All returns an iterator over all catalog categories. Pages are fetched lazily, as the iterator advances.
+// Any error, including the cancellation of ctx, stops the iteration and is available through Err.
+func (s *catalogCategories) All(ctx context.Context) *CatalogCategoriesIterator {
+	return &CatalogCategoriesIterator{pager: s.pager(ctx)}
+}
+
+// Next advances the iterator to the next category. It returns false when there are no more categories or an error occurred.
+func (it *CatalogCategoriesIterator) Next() bool {
+	if it.stopped() {
+		return false
+	}
+
+	for it.index >= len(it.categories) {
+		page := &CatalogCategoriesPage{}
+		if !it.fetch(page, &page.APIPage) {
+			return false
+		}
+		it.categories, it.index = page.Categories, 0
+	}
+
+	it.index++
+	return true
+}
+
+// Category returns the current category.
+func (it *CatalogCategoriesIterator) Category() CatalogCategory {
+	return it.categories[it.index-1]
+}
+
+func (s *catalogCategories) Get(ctx context.Context, id string) (*CatalogCategory, error) {
+	response := struct{ Category *CatalogCategory }{}
+	if err := s.get(ctx, id, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Category, nil
+}
+
+func (s *catalogCategories) Create(ctx context.Context, category *CatalogCategory) (*CatalogCategory, error) {
+	// copy input and remove ID from request body without modifying input
+	c := *category
+	c.ID = ""
+
+	response := struct{ Category *CatalogCategory }{}
+	if err := s.create(ctx, &c, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Category, nil
+}
+
+func (s *catalogCategories) Update(ctx context.Context, category *CatalogCategory) (*CatalogCategory, error) {
+	// copy input and remove ID from request body without modifying input
+	c := *category
+	c.ID = ""
+
+	response := struct{ Category *CatalogCategory }{}
+	if err := s.update(ctx, category.ID, &c, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Category, nil
+}
+
+func (s *catalogCategories) Delete(ctx context.Context, id string) error {
+	return s.service.delete(ctx, id)
+}
+
+// Import creates the categories of a JSON or CSV file. CSV files have a header row with the JSON field names of
+// CatalogCategory, i.e. "name". Categories which cannot be created are reported in the result; the returned error
+// is only set if the file cannot be decoded or ctx is done.
+func (s *catalogCategories) Import(ctx context.Context, r io.Reader, format ImportFormat) (*ImportResult, error) {
+	var categories []CatalogCategory
+	if err := decodeImport(r, format, nil, &categories); err != nil {
+		return nil, err
+	}
+
+	return importItems(ctx, len(categories), func(i int) (string, error) {
+		_, err := s.Create(ctx, &categories[i])
+		return categories[i].Name, err
+	})
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// CatalogEvent is an event of the data catalog, which tracking plans are built from.
+type CatalogEvent struct {
+	ID          string     `json:"id,omitempty"`
+	Name        string     `json:"name"`
+	Type        string     `json:"eventType"`
+	Description string     `json:"description,omitempty"`
+	CategoryID  string     `json:"categoryId,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+}
+
+type catalogEvents struct {
+	*service
+}
+
+type CatalogEventsPage struct {
+	APIPage
+	Events []CatalogEvent `json:"events"`
+}
+
+func (s *catalogEvents) Next(ctx context.Context, paging Paging) (*CatalogEventsPage, error) {
+	page := &CatalogEventsPage{}
+	ok, err := s.service.next(ctx, paging, page)
+	if !ok {
+		page = nil
+	}
+	return page, err
+}
+
+func (s *catalogEvents) List(ctx context.Context) (*CatalogEventsPage, error) {
+	page := &CatalogEventsPage{}
+	if err := s.list(ctx, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// CatalogEventsIterator iterates over all catalog events, fetching pages on demand.
+type CatalogEventsIterator struct {
+	pager
+	events []CatalogEvent
+	index  int
+}
+
+// All returns an iterator over all catalog events. Pages are fetched lazily, as the iterator advances.
+// Any error, including the cancellation of ctx, stops the iteration and is available through Err.
+func (s *catalogEvents) All(ctx context.Context) *CatalogEventsIterator {
+	return &CatalogEventsIterator{pager: s.pager(ctx)}
+}
+
+// Next advances the iterator to the next event. It returns false when there are no more events or an error occurred.
+func (it *CatalogEventsIterator) Next() bool {
+	if it.stopped() {
+		return false
+	}
+
+	for it.index >= len(it.events) {
+		page := &CatalogEventsPage{}
+		if !it.fetch(page, &page.APIPage) {
+			return false
+		}
+		it.events, it.index = page.Events, 0
+	}
+
+	it.index++
+	return true
+}
+
+// Event returns the current event.
+func (it *CatalogEventsIterator) Event() CatalogEvent {
+	return it.events[it.index-1]
+}
+
+func (s *catalogEvents) Get(ctx context.Context, id string) (*CatalogEvent, error) {
+	response := struct{ Event *CatalogEvent }{}
+	if err := s.get(ctx, id, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Event, nil
+}
+
+func (s *catalogEvents) Create(ctx context.Context, event *CatalogEvent) (*CatalogEvent, error) {
+	// copy input and remove ID from request body without modifying input
+	e := *event
+	e.ID = ""
+
+	response := struct{ Event *CatalogEvent }{}
+	if err := s.create(ctx, &e, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Event, nil
+}
+
+func (s *catalogEvents) Update(ctx context.Context, event *CatalogEvent) (*CatalogEvent, error) {
+	// copy input and remove ID from request body without modifying input
+	e := *event
+	e.ID = ""
+
+	response := struct{ Event *CatalogEvent }{}
+	if err := s.update(ctx, event.ID, &e, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Event, nil
+}
+
+func (s *catalogEvents) Delete(ctx context.Context, id string) error {
+	return s.service.delete(ctx, id)
+}
+
+// Import creates the events of a JSON or CSV file. CSV files have a header row with the JSON field names of
+// CatalogEvent, e.g. "name,eventType,description,categoryId". Events which cannot be created are reported in
+// the result; the returned error is only set if the file cannot be decoded or ctx is done.
+func (s *catalogEvents) Import(ctx context.Context, r io.Reader, format ImportFormat) (*ImportResult, error) {
+	var events []CatalogEvent
+	if err := decodeImport(r, format, nil, &events); err != nil {
+		return nil, err
+	}
+
+	return importItems(ctx, len(events), func(i int) (string, error) {
+		_, err := s.Create(ctx, &events[i])
+		return events[i].Name, err
+	})
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// ImportFormat is the format of the files imported in the catalog.
+type ImportFormat string
+
+const (
+	// ImportJSON files hold a JSON array of items, e.g. [{"name": "Product Viewed", "eventType": "track"}].
+	ImportJSON ImportFormat = "json"
+	// ImportCSV files hold a header row naming the JSON fields of the items, e.g. "name,eventType", followed by a row per item.
+	ImportCSV ImportFormat = "csv"
+)
+
+var ErrInvalidImportFormat = fmt.Errorf("import format must be json or csv")
+
+// ImportResult reports the outcome of a bulk import. Items are created one by one, and the failure of an item
+// does not prevent the following ones from being imported.
+type ImportResult struct {
+	// Created is the number of items which were created.
+	Created int
+	Errors  []ImportError
+}
+
+// ImportError is the error of an item of a bulk import.
+type ImportError struct {
+	// Index is the position of the item in the file, starting at 0 and excluding the CSV header row.
+	Index int
+	Name  string
+	Err   error
+}
+
+func (e ImportError) Error() string {
+	return fmt.Sprintf("item %d ('%s'): %s", e.Index, e.Name, e.Err)
+}
+
+func (e ImportError) Unwrap() error {
+	return e.Err
+}
+
+// decodeImport decodes a file in the given format into items, a pointer to a slice. The values of CSV columns are
+// decoded as strings, except for jsonColumns which hold JSON values.
+func decodeImport(r io.Reader, format ImportFormat, jsonColumns map[string]bool, items interface{}) error {
+	switch format {
+	case ImportJSON:
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, items)
+
+	case ImportCSV:
+		reader := csv.NewReader(r)
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		header := rows[0]
+		records := make([]map[string]json.RawMessage, 0, len(rows)-1)
+		for _, row := range rows[1:] {
+			record := map[string]json.RawMessage{}
+			for i, value := range row {
+				if value == "" {
+					continue
+				}
+				if jsonColumns[header[i]] {
+					if !json.Valid([]byte(value)) {
+						return fmt.Errorf("invalid JSON in column '%s' of row %d", header[i], len(records)+1)
+					}
+					record[header[i]] = json.RawMessage(value)
+					continue
+				}
+
+				encoded, err := json.Marshal(value)
+				if err != nil {
+					return err
+				}
+				record[header[i]] = encoded
+			}
+			records = append(records, record)
+		}
+
+		data, err := json.Marshal(records)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, items)
+
+	default:
+		return ErrInvalidImportFormat
+	}
+}
+
+// importItems creates count items with create, which returns the name of the item and the error creating it, if any.
+// It stops early if ctx is done.
+func importItems(ctx context.Context, count int, create func(i int) (string, error)) (*ImportResult, error) {
+	result := &ImportResult{}
+	for i := 0; i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		name, err := create(i)
+		if err != nil {
+			result.Errors = append(result.Errors, ImportError{Index: i, Name: name, Err: err})
+			continue
+		}
+		result.Created++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// CatalogProperty is a property of the data catalog, which the rules of tracking plan events refer to.
+type CatalogProperty struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	// Config holds additional JSON Schema keywords constraining the values of the property, e.g. {"enum": ["a", "b"]}.
+	Config    json.RawMessage `json:"config,omitempty"`
+	CreatedAt *time.Time      `json:"createdAt,omitempty"`
+	UpdatedAt *time.Time      `json:"updatedAt,omitempty"`
+}
+
+type catalogProperties struct {
+	*service
+}
+
+type CatalogPropertiesPage struct {
+	APIPage
+	Properties []CatalogProperty `json:"properties"`
+}
+
+func (s *catalogProperties) Next(ctx context.Context, paging Paging) (*CatalogPropertiesPage, error) {
+	page := &CatalogPropertiesPage{}
+	ok, err := s.service.next(ctx, paging, page)
+	if !ok {
+		page = nil
+	}
+	return page, err
+}
+
+func (s *catalogProperties) List(ctx context.Context) (*CatalogPropertiesPage, error) {
+	page := &CatalogPropertiesPage{}
+	if err := s.list(ctx, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// CatalogPropertiesIterator iterates over all catalog properties, fetching pages on demand.
+type CatalogPropertiesIterator struct {
+	pager
+	properties []CatalogProperty
+	index      int
+}
+
+// All returns an iterator over all catalog properties. Pages are fetched lazily, as the iterator advances.
+// Any error, including the cancellation of ctx, stops the iteration and is available through Err.
+func (s *catalogProperties) All(ctx context.Context) *CatalogPropertiesIterator {
+	return &CatalogPropertiesIterator{pager: s.pager(ctx)}
+}
+
+// Next advances the iterator to the next property. It returns false when there are no more properties or an error occurred.
+func (it *CatalogPropertiesIterator) Next() bool {
+	if it.stopped() {
+		return false
+	}
+
+	for it.index >= len(it.properties) {
+		page := &CatalogPropertiesPage{}
+		if !it.fetch(page, &page.APIPage) {
+			return false
+		}
+		it.properties, it.index = page.Properties, 0
+	}
+
+	it.index++
+	return true
+}
+
+// Property returns the current property.
+func (it *CatalogPropertiesIterator) Property() CatalogProperty {
+	return it.properties[it.index-1]
+}
+
+func (s *catalogProperties) Get(ctx context.Context, id string) (*CatalogProperty, error) {
+	response := struct{ Property *CatalogProperty }{}
+	if err := s.get(ctx, id, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Property, nil
+}
+
+func (s *catalogProperties) Create(ctx context.Context, property *CatalogProperty) (*CatalogProperty, error) {
+	// copy input and remove ID from request body without modifying input
+	p := *property
+	p.ID = ""
+
+	response := struct{ Property *CatalogProperty }{}
+	if err := s.create(ctx, &p, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Property, nil
+}
+
+func (s *catalogProperties) Update(ctx context.Context, property *CatalogProperty) (*CatalogProperty, error) {
+	// copy input and remove ID from request body without modifying input
+	p := *property
+	p.ID = ""
+
+	response := struct{ Property *CatalogProperty }{}
+	if err := s.update(ctx, property.ID, &p, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Property, nil
+}
+
+func (s *catalogProperties) Delete(ctx context.Context, id string) error {
+	return s.service.delete(ctx, id)
+}
+
+// Import creates the properties of a JSON or CSV file. CSV files have a header row with the JSON field names of
+// CatalogProperty, e.g. "name,type,description,config", where config holds JSON. Properties which cannot be
+// created are reported in the result; the returned error is only set if the file cannot be decoded or ctx is done.
+func (s *catalogProperties) Import(ctx context.Context, r io.Reader, format ImportFormat) (*ImportResult, error) {
+	var properties []CatalogProperty
+	if err := decodeImport(r, format, map[string]bool{"config": true}, &properties); err != nil {
+		return nil, err
+	}
+
+	return importItems(ctx, len(properties), func(i int) (string, error) {
+		_, err := s.Create(ctx, &properties[i])
+		return properties[i].Name, err
+	})
+}
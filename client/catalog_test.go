@@ -0,0 +1,231 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCatalogEventsCRUD(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/catalog/events", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"events": [{ "id": "id-1", "name": "Product Viewed", "eventType": "track", "categoryId": "category-1" }],
+				"paging": { "total": 2, "next": "/catalog/events?page=2" }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/catalog/events?page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"events": [{ "id": "id-2", "name": "Signed Up", "eventType": "identify" }],
+				"paging": { "total": 2 }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/catalog/events", `{
+					"name": "Order Completed",
+					"eventType": "track"
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "event": { "id": "id-3", "name": "Order Completed", "eventType": "track" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "PUT", "https://api.rudderstack.com/v2/catalog/events/id-3", `{
+					"name": "Order Completed",
+					"eventType": "track",
+					"description": "some-description"
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "event": { "id": "id-3", "name": "Order Completed", "eventType": "track", "description": "some-description" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/catalog/events/id-3", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "event": { "id": "id-3", "name": "Order Completed" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "DELETE", "https://api.rudderstack.com/v2/catalog/events/id-3", "")
+			},
+			ResponseStatus: 204,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	var operations []string
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient), client.WithHook(client.HookFuncs{
+		OnRequest: func(ctx context.Context, req *client.RequestInfo) {
+			operations = append(operations, req.Operation)
+		},
+	}))
+	require.NoError(t, err)
+
+	page, err := c.CatalogEvents.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []client.CatalogEvent{{ID: "id-1", Name: "Product Viewed", Type: client.EventTypeTrack, CategoryID: "category-1"}}, page.Events)
+
+	page, err = c.CatalogEvents.Next(ctx, page.Paging)
+	require.NoError(t, err)
+	assert.Equal(t, []client.CatalogEvent{{ID: "id-2", Name: "Signed Up", Type: client.EventTypeIdentify}}, page.Events)
+
+	event, err := c.CatalogEvents.Create(ctx, &client.CatalogEvent{Name: "Order Completed", Type: client.EventTypeTrack})
+	require.NoError(t, err)
+	assert.Equal(t, "id-3", event.ID)
+
+	event.Description = "some-description"
+	event, err = c.CatalogEvents.Update(ctx, event)
+	require.NoError(t, err)
+	assert.Equal(t, "some-description", event.Description)
+
+	event, err = c.CatalogEvents.Get(ctx, "id-3")
+	require.NoError(t, err)
+	assert.Equal(t, "Order Completed", event.Name)
+
+	require.NoError(t, c.CatalogEvents.Delete(ctx, "id-3"))
+
+	assert.Equal(t, []string{
+		"catalog.events.List",
+		"catalog.events.List",
+		"catalog.events.Create",
+		"catalog.events.Update",
+		"catalog.events.Get",
+		"catalog.events.Delete",
+	}, operations)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientCatalogPropertiesImportCSV(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/catalog/properties", `{
+					"name": "price",
+					"type": "number",
+					"description": "price, in cents"
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "property": { "id": "id-1", "name": "price", "type": "number" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/catalog/properties", `{
+					"name": "currency",
+					"type": "string",
+					"config": {"enum": ["EUR", "USD"]}
+				}`)
+			},
+			ResponseStatus: 409,
+			ResponseBody:   `{ "error": "property already exists", "code": "conflict" }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/catalog/properties", `{
+					"name": "quantity",
+					"type": "integer"
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "property": { "id": "id-3", "name": "quantity", "type": "integer" } }`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	file := `name,type,description,config
+price,number,"price, in cents",
+currency,string,,"{""enum"": [""EUR"", ""USD""]}"
+quantity,integer,,
+`
+	result, err := c.CatalogProperties.Import(ctx, strings.NewReader(file), client.ImportCSV)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 1, result.Errors[0].Index)
+	assert.Equal(t, "currency", result.Errors[0].Name)
+	assert.ErrorIs(t, result.Errors[0], client.ErrConflict)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientCatalogCategoriesImportJSON(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/catalog/categories", `{ "name": "Ecommerce" }`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "category": { "id": "id-1", "name": "Ecommerce" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/catalog/categories", `{ "name": "Onboarding" }`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "category": { "id": "id-2", "name": "Onboarding" } }`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	result, err := c.CatalogCategories.Import(ctx, strings.NewReader(`[{"name": "Ecommerce"}, {"name": "Onboarding"}]`), client.ImportJSON)
+	require.NoError(t, err)
+	assert.Equal(t, &client.ImportResult{Created: 2}, result)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientCatalogImportInvalidFile(t *testing.T) {
+	ctx := context.Background()
+
+	httpClient := testutils.NewMockHTTPClient(t)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	_, err = c.CatalogEvents.Import(ctx, strings.NewReader(`name`), "xml")
+	assert.Equal(t, client.ErrInvalidImportFormat, err)
+
+	_, err = c.CatalogEvents.Import(ctx, strings.NewReader(`{"name": "Product Viewed"}`), client.ImportJSON)
+	var typeErr *json.UnmarshalTypeError
+	assert.ErrorAs(t, err, &typeErr)
+
+	_, err = c.CatalogProperties.Import(ctx, strings.NewReader("name,config\nprice,{"), client.ImportCSV)
+	assert.EqualError(t, err, "invalid JSON in column 'config' of row 1")
+
+	httpClient.AssertNumberOfCalls()
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -8,6 +9,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
+
+	"github.com/rudderlabs/rudder-api-go/internal/redact"
 )
 
 type HTTPClient interface {
@@ -19,10 +23,26 @@ type Client struct {
 	accessToken string
 	userAgent   string
 	httpClient  HTTPClient
+	retryPolicy *RetryPolicy
+	rateLimiter *rateLimiter
+	hooks       []Hook
+
+	configValidator *configValidator
+	dryRunRecorder  *DryRun
 
 	Sources      *sources
 	Destinations *destinations
 	Connections  *connections
+
+	Transformations         *transformations
+	TransformationLibraries *transformationLibraries
+	TrackingPlans           *trackingPlans
+	CatalogEvents           *catalogEvents
+	CatalogProperties       *catalogProperties
+	CatalogCategories       *catalogCategories
+	Regulations             *regulations
+	AuditLogs               *auditLogs
+	Definitions             *definitions
 }
 
 const BASE_URL_V2 = "https://api.rudderstack.com/v2"
@@ -31,6 +51,8 @@ var (
 	ErrEmptyAccessToken  = fmt.Errorf("access token cannot be empty")
 	ErrInvalidBaseURL    = fmt.Errorf("base url cannot be empty")
 	ErrInvalidHTTPClient = fmt.Errorf("http client cannot be nil")
+	ErrInvalidHook       = fmt.Errorf("hook cannot be nil")
+	ErrInvalidLogger     = fmt.Errorf("logger cannot be nil")
 )
 
 func New(accessToken string, options ...Option) (*Client, error) {
@@ -44,6 +66,15 @@ func New(accessToken string, options ...Option) (*Client, error) {
 	client.Sources = &sources{service: client.service("sources")}
 	client.Destinations = &destinations{service: client.service("destinations")}
 	client.Connections = &connections{service: client.service("connections")}
+	client.Transformations = &transformations{service: client.service("transformations")}
+	client.TransformationLibraries = &transformationLibraries{service: client.service("transformationLibraries"), transformations: client.Transformations}
+	client.TrackingPlans = &trackingPlans{service: client.service("trackingPlans")}
+	client.CatalogEvents = &catalogEvents{service: client.service("catalog/events")}
+	client.CatalogProperties = &catalogProperties{service: client.service("catalog/properties")}
+	client.CatalogCategories = &catalogCategories{service: client.service("catalog/categories")}
+	client.Regulations = &regulations{service: client.service("regulations")}
+	client.AuditLogs = &auditLogs{service: client.service("auditLogs")}
+	client.Definitions = &definitions{service: client.service("definitions")}
 
 	for _, o := range options {
 		if err := o(client); err != nil {
@@ -67,39 +98,174 @@ func (c *Client) URL(path string) string {
 }
 
 func (c *Client) Do(ctx context.Context, method, path string, body io.Reader) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, method, c.URL(path), body)
+	// read the request body once, so that it can be replayed on retries
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = ioutil.ReadAll(body); err != nil {
+			return nil, err
+		}
+	}
+
+	call := &CallInfo{Operation: operationFromContext(ctx), Method: method, Path: path}
+	for _, hook := range c.hooks {
+		if callHook, ok := hook.(CallHook); ok {
+			ctx = callHook.StartCall(ctx, call)
+		}
+	}
+
+	start := time.Now()
+	data, err := c.doWithRetries(ctx, call, payload)
+
+	for _, hook := range c.hooks {
+		if callHook, ok := hook.(CallHook); ok {
+			callHook.EndCall(ctx, call, time.Since(start), err)
+		}
+	}
+
+	return data, err
+}
+
+// doWithRetries performs the attempts of a request, according to the retry policy of the client.
+func (c *Client) doWithRetries(ctx context.Context, call *CallInfo, payload []byte) ([]byte, error) {
+	if c.retryPolicy == nil {
+		data, _, err := c.do(ctx, call, payload)
+		return data, err
+	}
+
+	var attempts []error
+	for attempt := 1; ; attempt++ {
+		data, header, err := c.do(ctx, call, payload)
+		if err == nil {
+			return data, nil
+		}
+
+		attempts = append(attempts, err)
+		if !c.retryPolicy.shouldRetry(ctx, call.Method, attempt, err) {
+			break
+		}
+
+		if err := sleep(ctx, c.retryPolicy.backoff(attempt, header)); err != nil {
+			attempts = append(attempts, err)
+			break
+		}
+	}
+
+	if len(attempts) == 1 {
+		return nil, attempts[0]
+	}
+
+	return nil, &RetryError{Attempts: attempts}
+}
+
+// do performs a single attempt of a request, returning the response body and headers.
+func (c *Client) do(ctx context.Context, call *CallInfo, payload []byte) ([]byte, http.Header, error) {
+	call.Attempts++
+	call.StatusCode = 0
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, call.Method, c.URL(call.Path), body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("User-Agent", c.userAgent)
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
 
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.wait(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var info *RequestInfo
+	if len(c.hooks) > 0 {
+		info = &RequestInfo{
+			Operation: call.Operation,
+			Method:    call.Method,
+			Path:      call.Path,
+			URL:       req.URL.String(),
+			Attempt:   call.Attempts,
+			Header:    redact.Header(req.Header),
+			Body:      redact.JSON(payload),
+			header:    req.Header,
+		}
+		for _, hook := range c.hooks {
+			hook.BeforeRequest(ctx, info)
+		}
+	}
+
+	start := time.Now()
+	statusCode, header, data, err := c.send(req)
+	call.StatusCode = statusCode
+
+	if res := capturedResponse(ctx); res != nil && statusCode != 0 {
+		*res = Response{
+			Method:     req.Method,
+			URL:        req.URL.String(),
+			StatusCode: statusCode,
+			RequestID:  requestID(header),
+			Header:     header,
+			Body:       data,
+		}
+	}
+
+	if info != nil {
+		res := newResponseInfo(info, statusCode, header, redact.JSON(data), time.Since(start), err)
+		for _, hook := range c.hooks {
+			hook.AfterResponse(ctx, res)
+		}
+	}
+
+	if err != nil {
+		return nil, header, err
+	}
+
+	return data, header, nil
+}
+
+// send sends a request and reads its response. The response body is returned even for API errors.
+func (c *Client) send(req *http.Request) (int, http.Header, []byte, error) {
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return 0, nil, nil, err
 	}
 	defer res.Body.Close()
+
+	if c.rateLimiter != nil {
+		c.rateLimiter.observe(res.StatusCode, res.Header)
+	}
+
 	data, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return res.StatusCode, res.Header, nil, err
 	}
 
 	// check if response has an error status code and parse API error accordingly
 	if res.StatusCode < 200 || res.StatusCode > 299 {
-		apiError := &APIError{HTTPStatusCode: res.StatusCode}
+		apiError := &APIError{}
 		if len(data) > 0 {
-			err := json.Unmarshal(data, apiError)
-			if err != nil {
-				return nil, fmt.Errorf("could not parse error response from API: %w", err)
+			if err := json.Unmarshal(data, apiError); err != nil {
+				// the body is not an API error, e.g. an HTML page from a proxy: keep it raw
+				apiError = &APIError{Message: http.StatusText(res.StatusCode)}
 			}
 		}
 
-		return nil, apiError
+		apiError.HTTPStatusCode = res.StatusCode
+		apiError.Method = req.Method
+		apiError.URL = req.URL.String()
+		apiError.RequestID = requestID(res.Header)
+		apiError.Header = res.Header
+		apiError.Body = data
+		return res.StatusCode, res.Header, data, apiError
 	}
 
-	return data, nil
+	return res.StatusCode, res.Header, data, nil
 }
 
 func (c *Client) service(basePath string) *service {
@@ -3,6 +3,9 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+
+	"github.com/rudderlabs/rudder-api-go/internal/jsonmerge"
 )
 
 type Paging struct {
@@ -19,8 +22,50 @@ type APIError struct {
 	Message        string          `json:"error"`
 	ErrorCode      string          `json:"code"`
 	Details        json.RawMessage `json:"details"`
+
+	// Method and URL of the request, and RequestID, Header and raw Body of the response
+	Method    string      `json:"-"`
+	URL       string      `json:"-"`
+	RequestID string      `json:"-"`
+	Header    http.Header `json:"-"`
+	Body      []byte      `json:"-"`
 }
 
 func (e *APIError) Error() string {
-	return fmt.Sprintf("http status code: %d, error code: '%s', error: '%s'", e.HTTPStatusCode, e.ErrorCode, e.Message)
+	message := fmt.Sprintf("http status code: %d, error code: '%s', error: '%s'", e.HTTPStatusCode, e.ErrorCode, e.Message)
+	if e.RequestID != "" {
+		message += fmt.Sprintf(", request id: '%s'", e.RequestID)
+	}
+	return message
+}
+
+// String returns a pointer to the given string, e.g. to set a field of a patch.
+func String(v string) *string {
+	return &v
+}
+
+// Bool returns a pointer to the given bool, e.g. to set a field of a patch.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// applyPatch applies patch, encoded as a JSON merge patch, to the JSON encoding of resource, and decodes the result
+// into patched.
+func applyPatch(resource interface{}, patch interface{}, patched interface{}) error {
+	document, err := json.Marshal(resource)
+	if err != nil {
+		return err
+	}
+
+	mergePatch, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	merged, err := jsonmerge.Patch(document, mergePatch)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(merged, patched)
 }
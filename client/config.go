@@ -0,0 +1,174 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var ErrConfigTypeMismatch = fmt.Errorf("configuration type does not match resource type")
+
+// RawConfig is the configuration of a resource type which has no registered typed configuration.
+// It holds the configuration as it is returned by the API.
+type RawConfig struct {
+	Type string
+	JSON json.RawMessage
+}
+
+func (c *RawConfig) DestinationType() string {
+	return c.Type
+}
+
+func (c *RawConfig) SourceType() string {
+	return c.Type
+}
+
+func isNullConfig(data json.RawMessage) bool {
+	return len(data) == 0 || string(data) == "null"
+}
+
+// decodeConfig decodes a raw configuration into the typed configuration v.
+func decodeConfig(data json.RawMessage, v interface{}) error {
+	if isNullConfig(data) {
+		return nil
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// encodeConfig encodes the typed configuration v on top of the raw configuration data. Fields of data which
+// are unknown to v, or which have not been modified, are kept exactly as they are, so that decoding and then
+// encoding a configuration is lossless. Fields of v that are not present in data are only added if they are set.
+// Modified fields are encoded with encodeOnto, so that the unknown keys of nested objects are kept as well.
+func encodeConfig(data json.RawMessage, v interface{}) (json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if !isNullConfig(data) {
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("typed configuration must be a struct, got %s", value.Kind())
+	}
+
+	changed := false
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := jsonFieldName(field)
+		if name == "" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		current, ok := fields[name]
+		if ok && unchanged(current, fieldValue) {
+			continue
+		}
+		if !ok && fieldValue.IsZero() {
+			continue
+		}
+
+		var encoded json.RawMessage
+		var err error
+		if ok {
+			encoded, err = encodeOnto(current, fieldValue)
+		} else {
+			encoded, err = json.Marshal(fieldValue.Interface())
+		}
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = encoded
+		changed = true
+	}
+
+	if !changed && !isNullConfig(data) {
+		return data, nil
+	}
+
+	return json.Marshal(fields)
+}
+
+// unchanged reports whether the raw value current decodes to value. Keys of current unknown to value are ignored.
+func unchanged(current json.RawMessage, value reflect.Value) bool {
+	decoded := reflect.New(value.Type())
+	if err := json.Unmarshal(current, decoded.Interface()); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(decoded.Elem().Interface(), value.Interface())
+}
+
+// encodeOnto encodes a modified value on top of its current raw value. Structs are encoded onto objects with
+// encodeConfig, keeping the keys they do not know. Elements of slices which have not been modified are kept as they
+// are, even if they moved, while new or modified elements are encoded from scratch: array elements are replaced
+// wholesale, dropping their unknown keys.
+func encodeOnto(current json.RawMessage, value reflect.Value) (json.RawMessage, error) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if !value.IsNil() && value.Elem().Kind() == reflect.Struct && isJSONObject(current) {
+			return encodeConfig(current, value.Interface())
+		}
+	case reflect.Struct:
+		if isJSONObject(current) {
+			return encodeConfig(current, value.Interface())
+		}
+	case reflect.Slice:
+		var elements []json.RawMessage
+		if !value.IsNil() && json.Unmarshal(current, &elements) == nil {
+			return encodeElements(elements, value)
+		}
+	}
+
+	return json.Marshal(value.Interface())
+}
+
+// encodeElements encodes the elements of a slice, reusing the raw elements of current which they match.
+func encodeElements(current []json.RawMessage, value reflect.Value) (json.RawMessage, error) {
+	used := make([]bool, len(current))
+	elements := make([]json.RawMessage, value.Len())
+	for i := range elements {
+		element := value.Index(i)
+		for j, raw := range current {
+			if !used[j] && unchanged(raw, element) {
+				used[j], elements[i] = true, raw
+				break
+			}
+		}
+
+		if elements[i] == nil {
+			encoded, err := json.Marshal(element.Interface())
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = encoded
+		}
+	}
+
+	return json.Marshal(elements)
+}
+
+func isJSONObject(data json.RawMessage) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '{'
+}
+
+// jsonFieldName returns the JSON name of an exported struct field, or an empty string if it is not serialized.
+func jsonFieldName(field reflect.StructField) string {
+	if field.PkgPath != "" {
+		return ""
+	}
+
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
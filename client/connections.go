@@ -14,6 +14,23 @@ type Connection struct {
 	UpdatedAt     *time.Time `json:"updatedAt,omitempty"`
 }
 
+// ConnectionPatch is a partial update of a connection. Nil fields are left untouched.
+type ConnectionPatch struct {
+	IsEnabled *bool `json:"enabled,omitempty"`
+}
+
+// ConnectionFilter selects connections for bulk operations, e.g. all the connections of a source.
+// Empty fields match all connections.
+type ConnectionFilter struct {
+	SourceID      string
+	DestinationID string
+}
+
+func (f ConnectionFilter) matches(connection Connection) bool {
+	return (f.SourceID == "" || f.SourceID == connection.SourceID) &&
+		(f.DestinationID == "" || f.DestinationID == connection.DestinationID)
+}
+
 type connections struct {
 	*service
 }
@@ -41,6 +58,42 @@ func (s *connections) List(ctx context.Context) (*ConnectionsPage, error) {
 	return page, nil
 }
 
+// ConnectionsIterator iterates over all connections, fetching pages on demand.
+type ConnectionsIterator struct {
+	pager
+	connections []Connection
+	index       int
+}
+
+// All returns an iterator over all connections. Pages are fetched lazily, as the iterator advances.
+// Any error, including the cancellation of ctx, stops the iteration and is available through Err.
+func (s *connections) All(ctx context.Context) *ConnectionsIterator {
+	return &ConnectionsIterator{pager: s.pager(ctx)}
+}
+
+// Next advances the iterator to the next connection. It returns false when there are no more connections or an error occurred.
+func (it *ConnectionsIterator) Next() bool {
+	if it.stopped() {
+		return false
+	}
+
+	for it.index >= len(it.connections) {
+		page := &ConnectionsPage{}
+		if !it.fetch(page, &page.APIPage) {
+			return false
+		}
+		it.connections, it.index = page.Connections, 0
+	}
+
+	it.index++
+	return true
+}
+
+// Connection returns the current connection.
+func (it *ConnectionsIterator) Connection() Connection {
+	return it.connections[it.index-1]
+}
+
 func (s *connections) Get(ctx context.Context, id string) (*Connection, error) {
 	response := struct{ Connection *Connection }{}
 	if err := s.get(ctx, id, &response); err != nil {
@@ -76,6 +129,70 @@ func (s *connections) Update(ctx context.Context, connection *Connection) (*Conn
 	return response.Connection, nil
 }
 
+// Patch updates the fields set in patch, leaving the other fields of the connection untouched. The connection is fetched,
+// patched and updated, so changes made between the two requests are overwritten.
+func (s *connections) Patch(ctx context.Context, id string, patch ConnectionPatch) (*Connection, error) {
+	connection, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patched := &Connection{}
+	if err := applyPatch(connection, &patch, patched); err != nil {
+		return nil, err
+	}
+
+	return s.Update(ctx, patched)
+}
+
+// Enable enables a connection, leaving its other fields untouched.
+func (s *connections) Enable(ctx context.Context, id string) (*Connection, error) {
+	return s.Patch(ctx, id, ConnectionPatch{IsEnabled: Bool(true)})
+}
+
+// Disable disables a connection, leaving its other fields untouched.
+func (s *connections) Disable(ctx context.Context, id string) (*Connection, error) {
+	return s.Patch(ctx, id, ConnectionPatch{IsEnabled: Bool(false)})
+}
+
+// EnableMatching enables all the connections matching filter, with at most concurrency requests at a time. Connections which
+// are already enabled are left untouched, and the other ones are updated as listed, overwriting any change
+// made in the meantime. An error is returned only if the connections cannot be listed.
+func (s *connections) EnableMatching(ctx context.Context, filter ConnectionFilter, concurrency int) (*BulkResult, error) {
+	return s.setEnabledMatching(ctx, filter, true, concurrency)
+}
+
+// DisableMatching disables all the connections matching filter, with at most concurrency requests at a time. Connections which
+// are already disabled are left untouched, and the other ones are updated as listed, overwriting any change
+// made in the meantime. An error is returned only if the connections cannot be listed.
+func (s *connections) DisableMatching(ctx context.Context, filter ConnectionFilter, concurrency int) (*BulkResult, error) {
+	return s.setEnabledMatching(ctx, filter, false, concurrency)
+}
+
+func (s *connections) setEnabledMatching(ctx context.Context, filter ConnectionFilter, enabled bool, concurrency int) (*BulkResult, error) {
+	var targets []bulkTarget
+	matching := map[string]Connection{}
+	it := s.All(ctx)
+	for it.Next() {
+		connection := it.Connection()
+		if filter.matches(connection) {
+			targets = append(targets, bulkTarget{id: connection.ID, skip: connection.IsEnabled == enabled})
+			matching[connection.ID] = connection
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	// the listed connections are updated as they are, saving a request per connection
+	return runBulk(ctx, targets, concurrency, func(ctx context.Context, id string) error {
+		connection := matching[id]
+		connection.IsEnabled = enabled
+		_, err := s.Update(ctx, &connection)
+		return err
+	}), nil
+}
+
 func (s *connections) Delete(ctx context.Context, id string) error {
 	return s.service.delete(ctx, id)
 }
@@ -217,3 +217,96 @@ func TestClientConnectionsUpdate(t *testing.T) {
 
 	httpClient.AssertNumberOfCalls()
 }
+
+func TestClientConnectionsPatch(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/connections/some-id", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"connection": {
+					"id": "some-id",
+					"sourceId": "some-source-id",
+					"destinationId": "some-destination-id",
+					"enabled": false
+				}
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "PUT", "https://api.rudderstack.com/v2/connections/some-id", `{
+					"sourceId": "some-source-id",
+					"destinationId": "some-destination-id",
+					"enabled": true
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"connection": {
+					"id": "some-id",
+					"sourceId": "some-source-id",
+					"destinationId": "some-destination-id",
+					"enabled": true
+				}
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	connection, err := c.Connections.Patch(ctx, "some-id", client.ConnectionPatch{IsEnabled: client.Bool(true)})
+	require.NoError(t, err)
+	assert.Equal(t, "some-source-id", connection.SourceID)
+	assert.True(t, connection.IsEnabled)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientConnectionsAll(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/connections", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"connections": [],
+				"paging": { "total": 1, "next": "/connections?page=2" }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/connections?page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"connections": [{ "id": "id-1", "sourceId": "source-1", "destinationId": "destination-1" }],
+				"paging": { "total": 1 }
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	var connections []client.Connection
+	it := c.Connections.All(ctx)
+	for it.Next() {
+		connections = append(connections, it.Connection())
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []client.Connection{{ID: "id-1", SourceID: "source-1", DestinationID: "destination-1"}}, connections)
+
+	httpClient.AssertNumberOfCalls()
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rudderlabs/rudder-api-go/internal/jsonschema"
+)
+
+// Connection modes of definitions
+const (
+	ConnectionModeCloud  = "cloud"
+	ConnectionModeDevice = "device"
+	ConnectionModeHybrid = "hybrid"
+)
+
+// Definition describes a type of sources or destinations.
+type Definition struct {
+	// Type is the value of Source.Type or Destination.Type for this definition, e.g. "POSTGRES".
+	Type        string `json:"type"`
+	DisplayName string `json:"displayName"`
+	// ConfigSchema is the JSON Schema of the configuration of sources or destinations of this type, if any.
+	ConfigSchema    json.RawMessage `json:"configSchema,omitempty"`
+	ConnectionModes []string        `json:"connectionModes,omitempty"`
+}
+
+// ValidateConfig checks config against the config schema of the definition. It returns a *ConfigValidationError
+// listing every violation, or nil if config is valid or the definition has no schema.
+func (d *Definition) ValidateConfig(config json.RawMessage) error {
+	if len(d.ConfigSchema) == 0 {
+		return nil
+	}
+
+	schema, err := jsonschema.Compile(d.ConfigSchema)
+	if err != nil {
+		return fmt.Errorf("config schema of type '%s': %w", d.Type, err)
+	}
+
+	return validateConfig(schema, d.Type, config)
+}
+
+func validateConfig(schema *jsonschema.Schema, typ string, config json.RawMessage) error {
+	if len(config) == 0 {
+		config = json.RawMessage("{}")
+	}
+
+	violations, err := schema.Validate(config)
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	validationErr := &ConfigValidationError{Type: typ}
+	for _, v := range violations {
+		field := "config"
+		if v.Path != "" {
+			field += "." + v.Path
+		}
+		validationErr.Errors = append(validationErr.Errors, FieldError{Field: field, Message: v.Message, Code: v.Keyword})
+	}
+	return validationErr
+}
+
+// ConfigValidationError reports every violation of the config schema of a source or destination type, found before
+// sending a request. It matches ErrValidation with errors.Is, like API validation errors.
+type ConfigValidationError struct {
+	Type   string
+	Errors []FieldError
+}
+
+func (e *ConfigValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldError := range e.Errors {
+		messages = append(messages, fieldError.Error())
+	}
+	return fmt.Sprintf("invalid config for type '%s': %s", e.Type, strings.Join(messages, "; "))
+}
+
+func (e *ConfigValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// ValidationErrors returns the violations of the config schema, as APIError.ValidationErrors does for API errors.
+func (e *ConfigValidationError) ValidationErrors() []FieldError {
+	return e.Errors
+}
+
+type definitions struct {
+	*service
+}
+
+type DefinitionsPage struct {
+	APIPage
+	Definitions []Definition `json:"definitions"`
+}
+
+func (s *definitions) all(ctx context.Context, operation, kind string) ([]Definition, error) {
+	var all []Definition
+	paging := Paging{Next: s.basePath + "/" + kind}
+	for {
+		page := &DefinitionsPage{}
+		ok, err := s.nextPage(ctx, operation, paging, page)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return all, nil
+		}
+
+		all = append(all, page.Definitions...)
+		paging = page.Paging
+	}
+}
+
+// Sources returns the definitions of all source types.
+func (s *definitions) Sources(ctx context.Context) ([]Definition, error) {
+	return s.all(ctx, "Sources", "sources")
+}
+
+// Destinations returns the definitions of all destination types.
+func (s *definitions) Destinations(ctx context.Context) ([]Definition, error) {
+	return s.all(ctx, "Destinations", "destinations")
+}
+
+// Source returns the definition of a source type, e.g. "HTTP".
+func (s *definitions) Source(ctx context.Context, typ string) (*Definition, error) {
+	response := struct{ Definition *Definition }{}
+	if err := s.action(ctx, "Source", "GET", []string{"sources", typ}, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Definition, nil
+}
+
+// Destination returns the definition of a destination type, e.g. "POSTGRES".
+func (s *definitions) Destination(ctx context.Context, typ string) (*Definition, error) {
+	response := struct{ Definition *Definition }{}
+	if err := s.action(ctx, "Destination", "GET", []string{"destinations", typ}, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Definition, nil
+}
+
+// ValidateSource fetches the definition of the type of source, and checks its config against the config schema.
+// An unknown type results in an error matching ErrNotFound.
+func (s *definitions) ValidateSource(ctx context.Context, source *Source) error {
+	definition, err := s.Source(ctx, source.Type)
+	if err != nil {
+		return err
+	}
+
+	return definition.ValidateConfig(source.Config)
+}
+
+// ValidateDestination fetches the definition of the type of destination, and checks its config against the config
+// schema. An unknown type results in an error matching ErrNotFound.
+func (s *definitions) ValidateDestination(ctx context.Context, destination *Destination) error {
+	definition, err := s.Destination(ctx, destination.Type)
+	if err != nil {
+		return err
+	}
+
+	return definition.ValidateConfig(destination.Config)
+}
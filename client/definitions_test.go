@@ -0,0 +1,151 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const postgresDefinition = `{
+	"type": "POSTGRES",
+	"displayName": "PostgreSQL",
+	"connectionModes": ["cloud"],
+	"configSchema": {
+		"type": "object",
+		"required": ["host", "port", "database"],
+		"properties": {
+			"host": { "type": "string" },
+			"port": { "type": "string", "pattern": "^[0-9]+$" },
+			"database": { "type": "string" },
+			"sslMode": { "enum": ["disable", "require"] }
+		}
+	}
+}`
+
+func TestClientDefinitionsList(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/definitions/destinations", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"definitions": [` + postgresDefinition + `],
+				"paging": { "total": 2, "next": "/definitions/destinations?page=2" }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/definitions/destinations?page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"definitions": [{ "type": "AM", "displayName": "Amplitude", "connectionModes": ["cloud", "device"] }],
+				"paging": { "total": 2 }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/definitions/sources", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"definitions": [{ "type": "HTTP", "displayName": "HTTP API", "connectionModes": ["cloud"] }],
+				"paging": { "total": 1 }
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	destinations, err := c.Definitions.Destinations(ctx)
+	require.NoError(t, err)
+	require.Len(t, destinations, 2)
+	assert.Equal(t, "POSTGRES", destinations[0].Type)
+	assert.Equal(t, "PostgreSQL", destinations[0].DisplayName)
+	assert.Equal(t, []string{client.ConnectionModeCloud}, destinations[0].ConnectionModes)
+	assert.NotEmpty(t, destinations[0].ConfigSchema)
+	assert.Equal(t, client.Definition{
+		Type:            "AM",
+		DisplayName:     "Amplitude",
+		ConnectionModes: []string{client.ConnectionModeCloud, client.ConnectionModeDevice},
+	}, destinations[1])
+
+	sources, err := c.Definitions.Sources(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []client.Definition{{Type: "HTTP", DisplayName: "HTTP API", ConnectionModes: []string{client.ConnectionModeCloud}}}, sources)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientDefinitionsValidateDestination(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/definitions/destinations/POSTGRES", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "definition": ` + postgresDefinition + ` }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/definitions/destinations/POSTGRES", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "definition": ` + postgresDefinition + ` }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/definitions/sources/UNKNOWN", "")
+			},
+			ResponseStatus: 404,
+			ResponseBody:   `{ "error": "definition not found", "code": "not_found" }`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	err = c.Definitions.ValidateDestination(ctx, &client.Destination{
+		Type:   "POSTGRES",
+		Config: json.RawMessage(`{"host": "localhost", "port": "5432", "database": "db"}`),
+	})
+	require.NoError(t, err)
+
+	err = c.Definitions.ValidateDestination(ctx, &client.Destination{
+		Type:   "POSTGRES",
+		Config: json.RawMessage(`{"host": "localhost", "port": 5432, "sslMode": "prefer"}`),
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, client.ErrValidation))
+	assert.EqualError(t, err, `invalid config for type 'POSTGRES': config.database: is required; config.port: must be of type string; config.sslMode: must be one of ["disable", "require"]`)
+
+	var validationErr *client.ConfigValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []client.FieldError{
+		{Field: "config.database", Message: "is required", Code: "required"},
+		{Field: "config.port", Message: "must be of type string", Code: "type"},
+		{Field: "config.sslMode", Message: `must be one of ["disable", "require"]`, Code: "enum"},
+	}, validationErr.ValidationErrors())
+
+	err = c.Definitions.ValidateSource(ctx, &client.Source{Type: "UNKNOWN"})
+	assert.True(t, errors.Is(err, client.ErrNotFound))
+
+	httpClient.AssertNumberOfCalls()
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"sync"
+)
+
+// DestinationConfig is implemented by the typed configurations of destinations.
+type DestinationConfig interface {
+	// DestinationType returns the destination type the configuration applies to, e.g. "POSTGRES".
+	DestinationType() string
+}
+
+var (
+	destinationConfigsMu sync.RWMutex
+	destinationConfigs   = map[string]func() DestinationConfig{
+		"POSTGRES":  func() DestinationConfig { return &PostgresConfig{} },
+		"WEBHOOK":   func() DestinationConfig { return &WebhookConfig{} },
+		"S3":        func() DestinationConfig { return &S3Config{} },
+		"BQ":        func() DestinationConfig { return &BigQueryConfig{} },
+		"SNOWFLAKE": func() DestinationConfig { return &SnowflakeConfig{} },
+		"RS":        func() DestinationConfig { return &RedshiftConfig{} },
+	}
+)
+
+// RegisterDestinationConfig registers the typed configuration used by Destination.DecodeConfig for the given
+// destination type, replacing any existing one. The factory must return a pointer to a struct.
+func RegisterDestinationConfig(destinationType string, factory func() DestinationConfig) {
+	destinationConfigsMu.Lock()
+	defer destinationConfigsMu.Unlock()
+	destinationConfigs[destinationType] = factory
+}
+
+func newDestinationConfig(destinationType string) DestinationConfig {
+	destinationConfigsMu.RLock()
+	defer destinationConfigsMu.RUnlock()
+	if factory, ok := destinationConfigs[destinationType]; ok {
+		return factory()
+	}
+	return nil
+}
+
+// DecodeConfig decodes the destination's configuration into the typed configuration registered for its type.
+// A *RawConfig is returned for types without a registered typed configuration.
+func (d *Destination) DecodeConfig() (DestinationConfig, error) {
+	config := newDestinationConfig(d.Type)
+	if config == nil {
+		return &RawConfig{Type: d.Type, JSON: d.Config}, nil
+	}
+
+	if err := decodeConfig(d.Config, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// EncodeConfig sets the destination's configuration from a typed configuration. Fields of the existing
+// configuration which are unknown to the typed configuration are preserved. If the destination has no
+// type yet, it is set from the configuration.
+func (d *Destination) EncodeConfig(config DestinationConfig) error {
+	if d.Type == "" {
+		d.Type = config.DestinationType()
+	} else if d.Type != config.DestinationType() {
+		return ErrConfigTypeMismatch
+	}
+
+	if raw, ok := config.(*RawConfig); ok {
+		d.Config = raw.JSON
+		return nil
+	}
+
+	data, err := encodeConfig(d.Config, config)
+	if err != nil {
+		return err
+	}
+
+	d.Config = data
+	return nil
+}
+
+// PostgresConfig is the configuration of POSTGRES destinations.
+type PostgresConfig struct {
+	Host             string `json:"host,omitempty"`
+	Port             string `json:"port,omitempty"`
+	Database         string `json:"database,omitempty"`
+	User             string `json:"user,omitempty"`
+	Password         string `json:"password,omitempty"`
+	Namespace        string `json:"namespace,omitempty"`
+	SSLMode          string `json:"sslMode,omitempty"`
+	SyncFrequency    string `json:"syncFrequency,omitempty"`
+	SyncStartAt      string `json:"syncStartAt,omitempty"`
+	UseRudderStorage bool   `json:"useRudderStorage,omitempty"`
+}
+
+func (c *PostgresConfig) DestinationType() string {
+	return "POSTGRES"
+}
+
+// WebhookHeader is a custom HTTP header sent by WEBHOOK destinations.
+type WebhookHeader struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// WebhookConfig is the configuration of WEBHOOK destinations.
+type WebhookConfig struct {
+	WebhookURL    string          `json:"webhookUrl,omitempty"`
+	WebhookMethod string          `json:"webhookMethod,omitempty"`
+	Headers       []WebhookHeader `json:"headers,omitempty"`
+}
+
+func (c *WebhookConfig) DestinationType() string {
+	return "WEBHOOK"
+}
+
+// S3Config is the configuration of S3 destinations.
+type S3Config struct {
+	BucketName    string `json:"bucketName,omitempty"`
+	Prefix        string `json:"prefix,omitempty"`
+	AccessKeyID   string `json:"accessKeyID,omitempty"`
+	AccessKey     string `json:"accessKey,omitempty"`
+	EnableSSE     bool   `json:"enableSSE,omitempty"`
+	RoleBasedAuth bool   `json:"roleBasedAuth,omitempty"`
+	IAMRoleARN    string `json:"iamRoleARN,omitempty"`
+}
+
+func (c *S3Config) DestinationType() string {
+	return "S3"
+}
+
+// BigQueryConfig is the configuration of BQ (Google BigQuery) destinations.
+type BigQueryConfig struct {
+	Project       string `json:"project,omitempty"`
+	Location      string `json:"location,omitempty"`
+	BucketName    string `json:"bucketName,omitempty"`
+	Prefix        string `json:"prefix,omitempty"`
+	Namespace     string `json:"namespace,omitempty"`
+	Credentials   string `json:"credentials,omitempty"`
+	SyncFrequency string `json:"syncFrequency,omitempty"`
+	SyncStartAt   string `json:"syncStartAt,omitempty"`
+}
+
+func (c *BigQueryConfig) DestinationType() string {
+	return "BQ"
+}
+
+// SnowflakeConfig is the configuration of SNOWFLAKE destinations.
+type SnowflakeConfig struct {
+	Account          string `json:"account,omitempty"`
+	Database         string `json:"database,omitempty"`
+	Warehouse        string `json:"warehouse,omitempty"`
+	User             string `json:"user,omitempty"`
+	Password         string `json:"password,omitempty"`
+	Role             string `json:"role,omitempty"`
+	Namespace        string `json:"namespace,omitempty"`
+	CloudProvider    string `json:"cloudProvider,omitempty"`
+	SyncFrequency    string `json:"syncFrequency,omitempty"`
+	SyncStartAt      string `json:"syncStartAt,omitempty"`
+	UseRudderStorage bool   `json:"useRudderStorage,omitempty"`
+}
+
+func (c *SnowflakeConfig) DestinationType() string {
+	return "SNOWFLAKE"
+}
+
+// RedshiftConfig is the configuration of RS (Amazon Redshift) destinations.
+type RedshiftConfig struct {
+	Host             string `json:"host,omitempty"`
+	Port             string `json:"port,omitempty"`
+	Database         string `json:"database,omitempty"`
+	User             string `json:"user,omitempty"`
+	Password         string `json:"password,omitempty"`
+	Namespace        string `json:"namespace,omitempty"`
+	BucketName       string `json:"bucketName,omitempty"`
+	AccessKeyID      string `json:"accessKeyID,omitempty"`
+	AccessKey        string `json:"accessKey,omitempty"`
+	SyncFrequency    string `json:"syncFrequency,omitempty"`
+	SyncStartAt      string `json:"syncStartAt,omitempty"`
+	UseRudderStorage bool   `json:"useRudderStorage,omitempty"`
+}
+
+func (c *RedshiftConfig) DestinationType() string {
+	return "RS"
+}
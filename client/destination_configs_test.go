@@ -0,0 +1,106 @@
+package client_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestinationDecodeConfig(t *testing.T) {
+	destination := &client.Destination{
+		Type:   "POSTGRES",
+		Config: json.RawMessage(`{"host": "example.com", "port": "5432", "user": "rudder", "someUnknownField": [1, 2]}`),
+	}
+
+	config, err := destination.DecodeConfig()
+	require.NoError(t, err)
+	assert.Equal(t, &client.PostgresConfig{Host: "example.com", Port: "5432", User: "rudder"}, config)
+}
+
+func TestDestinationEncodeConfigLossless(t *testing.T) {
+	original := json.RawMessage(`{"host": "example.com", "port": "5432", "someUnknownField": [1, 2]}`)
+	destination := &client.Destination{Type: "POSTGRES", Config: original}
+
+	config, err := destination.DecodeConfig()
+	require.NoError(t, err)
+	require.NoError(t, destination.EncodeConfig(config))
+	assert.Equal(t, string(original), string(destination.Config))
+
+	config.(*client.PostgresConfig).Host = "other.example.com"
+	config.(*client.PostgresConfig).Password = "secret"
+	require.NoError(t, destination.EncodeConfig(config))
+	assert.JSONEq(t, `{"host": "other.example.com", "port": "5432", "password": "secret", "someUnknownField": [1, 2]}`, string(destination.Config))
+}
+
+func TestDestinationEncodeConfigLosslessNested(t *testing.T) {
+	original := json.RawMessage(`{"webhookUrl": "https://example.com", "headers": [{"from": "X-Key", "to": "value", "secret": true}]}`)
+	destination := &client.Destination{Type: "WEBHOOK", Config: original}
+
+	config, err := destination.DecodeConfig()
+	require.NoError(t, err)
+	webhook := config.(*client.WebhookConfig)
+
+	// unchanged elements keep their unknown keys, even when moved
+	webhook.Headers = append([]client.WebhookHeader{{From: "X-Other", To: "other"}}, webhook.Headers...)
+	require.NoError(t, destination.EncodeConfig(config))
+	assert.JSONEq(t, `{
+		"webhookUrl": "https://example.com",
+		"headers": [{"from": "X-Other", "to": "other"}, {"from": "X-Key", "to": "value", "secret": true}]
+	}`, string(destination.Config))
+
+	// modified elements are replaced wholesale
+	webhook.Headers[1].To = "new-value"
+	require.NoError(t, destination.EncodeConfig(config))
+	assert.JSONEq(t, `{
+		"webhookUrl": "https://example.com",
+		"headers": [{"from": "X-Other", "to": "other"}, {"from": "X-Key", "to": "new-value"}]
+	}`, string(destination.Config))
+}
+
+func TestDestinationEncodeConfigNew(t *testing.T) {
+	destination := &client.Destination{Name: "some-name"}
+
+	err := destination.EncodeConfig(&client.WebhookConfig{
+		WebhookURL: "https://example.com/hook",
+		Headers:    []client.WebhookHeader{{From: "X-Key", To: "value"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "WEBHOOK", destination.Type)
+	assert.JSONEq(t, `{"webhookUrl": "https://example.com/hook", "headers": [{"from": "X-Key", "to": "value"}]}`, string(destination.Config))
+}
+
+func TestDestinationEncodeConfigTypeMismatch(t *testing.T) {
+	destination := &client.Destination{Type: "POSTGRES"}
+	assert.Equal(t, client.ErrConfigTypeMismatch, destination.EncodeConfig(&client.S3Config{}))
+}
+
+func TestDestinationDecodeConfigUnknownType(t *testing.T) {
+	destination := &client.Destination{Type: "SOME_TYPE", Config: json.RawMessage(`{"key": "val"}`)}
+
+	config, err := destination.DecodeConfig()
+	require.NoError(t, err)
+	assert.Equal(t, &client.RawConfig{Type: "SOME_TYPE", JSON: json.RawMessage(`{"key": "val"}`)}, config)
+
+	require.NoError(t, destination.EncodeConfig(&client.RawConfig{Type: "SOME_TYPE", JSON: json.RawMessage(`{"key": "other"}`)}))
+	assert.Equal(t, `{"key": "other"}`, string(destination.Config))
+}
+
+type customDestinationConfig struct {
+	Key string `json:"key"`
+}
+
+func (c *customDestinationConfig) DestinationType() string {
+	return "CUSTOM"
+}
+
+func TestRegisterDestinationConfig(t *testing.T) {
+	client.RegisterDestinationConfig("CUSTOM", func() client.DestinationConfig { return &customDestinationConfig{} })
+
+	destination := &client.Destination{Type: "CUSTOM", Config: json.RawMessage(`{"key": "val"}`)}
+	config, err := destination.DecodeConfig()
+	require.NoError(t, err)
+	assert.Equal(t, &customDestinationConfig{Key: "val"}, config)
+}
@@ -16,6 +16,24 @@ type Destination struct {
 	UpdatedAt *time.Time      `json:"updatedAt,omitempty"`
 }
 
+// DestinationPatch is a partial update of a destination. Nil fields are left untouched.
+type DestinationPatch struct {
+	Name      *string `json:"name,omitempty"`
+	IsEnabled *bool   `json:"enabled,omitempty"`
+	// Config is a JSON merge patch of the config: its fields are set recursively, and fields set to null are removed.
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// DestinationFilter selects destinations for bulk operations, e.g. all the destinations of type WEBHOOK.
+// Empty fields match all destinations.
+type DestinationFilter struct {
+	Type string
+}
+
+func (f DestinationFilter) matches(destination Destination) bool {
+	return f.Type == "" || f.Type == destination.Type
+}
+
 type destinations struct {
 	*service
 }
@@ -43,6 +61,42 @@ func (s *destinations) List(ctx context.Context) (*DestinationsPage, error) {
 	return page, nil
 }
 
+// DestinationsIterator iterates over all destinations, fetching pages on demand.
+type DestinationsIterator struct {
+	pager
+	destinations []Destination
+	index        int
+}
+
+// All returns an iterator over all destinations. Pages are fetched lazily, as the iterator advances.
+// Any error, including the cancellation of ctx, stops the iteration and is available through Err.
+func (s *destinations) All(ctx context.Context) *DestinationsIterator {
+	return &DestinationsIterator{pager: s.pager(ctx)}
+}
+
+// Next advances the iterator to the next destination. It returns false when there are no more destinations or an error occurred.
+func (it *DestinationsIterator) Next() bool {
+	if it.stopped() {
+		return false
+	}
+
+	for it.index >= len(it.destinations) {
+		page := &DestinationsPage{}
+		if !it.fetch(page, &page.APIPage) {
+			return false
+		}
+		it.destinations, it.index = page.Destinations, 0
+	}
+
+	it.index++
+	return true
+}
+
+// Destination returns the current destination.
+func (it *DestinationsIterator) Destination() Destination {
+	return it.destinations[it.index-1]
+}
+
 func (s *destinations) Get(ctx context.Context, id string) (*Destination, error) {
 	response := struct{ Destination *Destination }{}
 	if err := s.get(ctx, id, &response); err != nil {
@@ -53,6 +107,10 @@ func (s *destinations) Get(ctx context.Context, id string) (*Destination, error)
 }
 
 func (s *destinations) Create(ctx context.Context, destination *Destination) (*Destination, error) {
+	if err := s.client.validateConfig(ctx, "destination", destination.Type, destination.Config); err != nil {
+		return nil, err
+	}
+
 	// copy input and remove fields that should not be in request body without modifying input
 	dst := *destination
 	dst.ID = ""
@@ -66,6 +124,10 @@ func (s *destinations) Create(ctx context.Context, destination *Destination) (*D
 }
 
 func (s *destinations) Update(ctx context.Context, destination *Destination) (*Destination, error) {
+	if err := s.client.validateConfig(ctx, "destination", destination.Type, destination.Config); err != nil {
+		return nil, err
+	}
+
 	// copy input and remove ID from request body without modifying input
 	dst := *destination
 	dst.ID = ""
@@ -78,6 +140,70 @@ func (s *destinations) Update(ctx context.Context, destination *Destination) (*D
 	return response.Destination, nil
 }
 
+// Patch updates the fields set in patch, leaving the other fields of the destination untouched. The destination is fetched,
+// patched and updated, so changes made between the two requests are overwritten.
+func (s *destinations) Patch(ctx context.Context, id string, patch DestinationPatch) (*Destination, error) {
+	destination, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patched := &Destination{}
+	if err := applyPatch(destination, &patch, patched); err != nil {
+		return nil, err
+	}
+
+	return s.Update(ctx, patched)
+}
+
+// Enable enables a destination, leaving its other fields untouched.
+func (s *destinations) Enable(ctx context.Context, id string) (*Destination, error) {
+	return s.Patch(ctx, id, DestinationPatch{IsEnabled: Bool(true)})
+}
+
+// Disable disables a destination, leaving its other fields untouched.
+func (s *destinations) Disable(ctx context.Context, id string) (*Destination, error) {
+	return s.Patch(ctx, id, DestinationPatch{IsEnabled: Bool(false)})
+}
+
+// EnableMatching enables all the destinations matching filter, with at most concurrency requests at a time. Destinations which
+// are already enabled are left untouched, and the other ones are updated as listed, overwriting any change
+// made in the meantime. An error is returned only if the destinations cannot be listed.
+func (s *destinations) EnableMatching(ctx context.Context, filter DestinationFilter, concurrency int) (*BulkResult, error) {
+	return s.setEnabledMatching(ctx, filter, true, concurrency)
+}
+
+// DisableMatching disables all the destinations matching filter, with at most concurrency requests at a time. Destinations which
+// are already disabled are left untouched, and the other ones are updated as listed, overwriting any change
+// made in the meantime. An error is returned only if the destinations cannot be listed.
+func (s *destinations) DisableMatching(ctx context.Context, filter DestinationFilter, concurrency int) (*BulkResult, error) {
+	return s.setEnabledMatching(ctx, filter, false, concurrency)
+}
+
+func (s *destinations) setEnabledMatching(ctx context.Context, filter DestinationFilter, enabled bool, concurrency int) (*BulkResult, error) {
+	var targets []bulkTarget
+	matching := map[string]Destination{}
+	it := s.All(ctx)
+	for it.Next() {
+		destination := it.Destination()
+		if filter.matches(destination) {
+			targets = append(targets, bulkTarget{id: destination.ID, skip: destination.IsEnabled == enabled})
+			matching[destination.ID] = destination
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	// the listed destinations are updated as they are, saving a request per destination
+	return runBulk(ctx, targets, concurrency, func(ctx context.Context, id string) error {
+		destination := matching[id]
+		destination.IsEnabled = enabled
+		_, err := s.Update(ctx, &destination)
+		return err
+	}), nil
+}
+
 func (s *destinations) Delete(ctx context.Context, id string) error {
 	return s.service.delete(ctx, id)
 }
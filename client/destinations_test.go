@@ -235,3 +235,99 @@ func TestClientDestinationsUpdate(t *testing.T) {
 
 	httpClient.AssertNumberOfCalls()
 }
+
+func TestClientDestinationsPatch(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/destinations/some-id", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"destination": {
+					"id": "some-id",
+					"name": "some-name",
+					"type": "some-type",
+					"enabled": true,
+					"config": { "key1": "val1" }
+				}
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "PUT", "https://api.rudderstack.com/v2/destinations/some-id", `{
+					"name": "some-name",
+					"type": "some-type",
+					"enabled": false,
+					"config": { "key1": "val1" }
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"destination": {
+					"id": "some-id",
+					"name": "some-name",
+					"type": "some-type",
+					"enabled": false,
+					"config": { "key1": "val1" }
+				}
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	destination, err := c.Destinations.Patch(ctx, "some-id", client.DestinationPatch{IsEnabled: client.Bool(false)})
+	require.NoError(t, err)
+	assert.Equal(t, "some-name", destination.Name)
+	assert.False(t, destination.IsEnabled)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientDestinationsAll(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/destinations", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"destinations": [{ "id": "id-1" }],
+				"paging": { "total": 2, "next": "/destinations?page=2" }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/destinations?page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"destinations": [{ "id": "id-2" }],
+				"paging": { "total": 2 }
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	var ids []string
+	it := c.Destinations.All(ctx)
+	for it.Next() {
+		ids = append(ids, it.Destination().ID)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"id-1", "id-2"}, ids)
+
+	httpClient.AssertNumberOfCalls()
+}
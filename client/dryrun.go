@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var ErrInvalidDryRun = fmt.Errorf("dry run recorder cannot be nil")
+
+// DryRunCall is a mutating call which was recorded instead of being sent.
+type DryRunCall struct {
+	// Operation is the name of the service operation, e.g. "sources.Create".
+	Operation string
+	Method    string
+	Path      string
+	// Body is the JSON body of the request, or nil if it has none.
+	Body json.RawMessage
+}
+
+func (c DryRunCall) String() string {
+	if c.Body == nil {
+		return c.Method + " " + c.Path
+	}
+	return c.Method + " " + c.Path + " " + string(c.Body)
+}
+
+// DryRun records the mutating calls of services in dry run mode. It is safe for concurrent use.
+type DryRun struct {
+	mu    sync.Mutex
+	calls []DryRunCall
+	ids   int
+}
+
+// Calls returns the calls recorded so far, in order.
+func (d *DryRun) Calls() []DryRunCall {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]DryRunCall(nil), d.calls...)
+}
+
+// Reset forgets the calls recorded so far.
+func (d *DryRun) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.calls = nil
+}
+
+func (d *DryRun) record(call DryRunCall) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.calls = append(d.calls, call)
+}
+
+// placeholderID returns a unique ID for a resource created in dry run mode, e.g. "dryrun-1", so that the calls which
+// use the created resource can reference it.
+func (d *DryRun) placeholderID() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.ids++
+	return fmt.Sprintf("dryrun-%d", d.ids)
+}
+
+type dryRunKey struct{}
+
+// ContextWithDryRun returns a context putting the service calls made with it in dry run mode, recording their
+// mutating calls in recorder, whether or not the client was created with WithDryRun.
+func ContextWithDryRun(ctx context.Context, recorder *DryRun) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, recorder)
+}
+
+// dryRun returns the recorder of the calls made with ctx, or nil if they must be sent.
+func (c *Client) dryRun(ctx context.Context) *DryRun {
+	if recorder, ok := ctx.Value(dryRunKey{}).(*DryRun); ok && recorder != nil {
+		return recorder
+	}
+	return c.dryRunRecorder
+}
+
+// synthesize fills the result of a call which was not sent with the resource of its request body, as if the API had
+// echoed it back. Responses wrap the resource in a single field, e.g. struct{ Source *Source }. The ID of the
+// resource is set to id, if not empty.
+func synthesize(result interface{}, body []byte, id string) error {
+	if result == nil {
+		return nil
+	}
+
+	value := reflect.ValueOf(result).Elem()
+	if value.Kind() != reflect.Struct || value.NumField() != 1 {
+		return nil
+	}
+
+	field := value.Field(0)
+	if field.Kind() == reflect.Ptr {
+		field.Set(reflect.New(field.Type().Elem()))
+	} else {
+		field = field.Addr()
+	}
+
+	if body != nil {
+		if err := json.Unmarshal(body, field.Interface()); err != nil {
+			return err
+		}
+	}
+
+	if id != "" {
+		data, err := json.Marshal(map[string]string{"id": id})
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, field.Interface())
+	}
+
+	return nil
+}
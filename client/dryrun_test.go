@@ -0,0 +1,104 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientDryRun(t *testing.T) {
+	ctx := context.Background()
+
+	// only reads are sent
+	httpClient := testutils.NewMockHTTPClient(t, testutils.Call{
+		Validate: func(req *http.Request) bool {
+			return testutils.ValidateRequest(t, req, "GET", "https://example.com/sources/some-id", "")
+		},
+		ResponseStatus: 200,
+		ResponseBody:   `{"source": {"id": "some-id", "name": "some-name"}}`,
+	})
+
+	recorder := &client.DryRun{}
+	c, err := client.New("some-access-token",
+		client.WithBaseURL("https://example.com"),
+		client.WithHTTPClient(httpClient),
+		client.WithDryRun(recorder))
+	require.NoError(t, err)
+
+	source, err := c.Sources.Get(ctx, "some-id")
+	require.NoError(t, err)
+	assert.Equal(t, "some-name", source.Name)
+
+	created, err := c.Sources.Create(ctx, &client.Source{Name: "new-name", Type: "HTTP", IsEnabled: true, Config: json.RawMessage(`{}`)})
+	require.NoError(t, err)
+	assert.Equal(t, &client.Source{ID: "dryrun-1", Name: "new-name", Type: "HTTP", IsEnabled: true, Config: json.RawMessage(`{}`)}, created)
+
+	other, err := c.Sources.Create(ctx, &client.Source{Name: "other-name", Type: "HTTP", Config: json.RawMessage(`{}`)})
+	require.NoError(t, err)
+	assert.Equal(t, "dryrun-2", other.ID)
+
+	updated, err := c.Destinations.Update(ctx, &client.Destination{ID: "some-destination-id", Name: "some-destination", Type: "WEBHOOK"})
+	require.NoError(t, err)
+	assert.Equal(t, "some-destination-id", updated.ID)
+	assert.Equal(t, "some-destination", updated.Name)
+
+	require.NoError(t, c.Connections.Delete(ctx, "some-connection-id"))
+
+	version, err := c.Transformations.Publish(ctx, "some-transformation-id")
+	require.NoError(t, err)
+	assert.NotNil(t, version)
+
+	calls := recorder.Calls()
+	require.Len(t, calls, 5)
+	assert.Equal(t, "sources.Create", calls[0].Operation)
+	assert.Equal(t, "POST", calls[0].Method)
+	assert.Equal(t, "sources", calls[0].Path)
+	assert.JSONEq(t, `{"name": "new-name", "type": "HTTP", "enabled": true, "config": {}}`, string(calls[0].Body))
+
+	assert.Equal(t, "destinations.Update", calls[2].Operation)
+	assert.Equal(t, "PUT", calls[2].Method)
+	assert.Equal(t, "destinations/some-destination-id", calls[2].Path)
+
+	assert.Equal(t, "DELETE connections/some-connection-id", calls[3].String())
+	assert.Equal(t, "POST transformations/some-transformation-id/publish", calls[4].String())
+
+	recorder.Reset()
+	assert.Empty(t, recorder.Calls())
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientDryRunContext(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t, testutils.Call{
+		Validate: func(req *http.Request) bool {
+			return testutils.ValidateRequest(t, req, "DELETE", "https://example.com/sources/some-id", "")
+		},
+		ResponseStatus: 204,
+	})
+
+	c, err := client.New("some-access-token",
+		client.WithBaseURL("https://example.com"),
+		client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	recorder := &client.DryRun{}
+	require.NoError(t, c.Sources.Delete(client.ContextWithDryRun(context.Background(), recorder), "other-id"))
+	require.NoError(t, c.Sources.Delete(context.Background(), "some-id"))
+
+	calls := recorder.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "DELETE sources/other-id", calls[0].String())
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientOptionDryRunNil(t *testing.T) {
+	_, err := client.New("some-access-token", client.WithDryRun(nil))
+	assert.Equal(t, client.ErrInvalidDryRun, err)
+}
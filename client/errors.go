@@ -0,0 +1,121 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// Sentinel errors matching API errors of a given kind with errors.Is, e.g. errors.Is(err, client.ErrNotFound).
+var (
+	ErrNotFound     = fmt.Errorf("resource not found")
+	ErrConflict     = fmt.Errorf("resource conflict")
+	ErrUnauthorized = fmt.Errorf("unauthorized")
+	ErrForbidden    = fmt.Errorf("forbidden")
+	ErrRateLimited  = fmt.Errorf("rate limited")
+	ErrValidation   = fmt.Errorf("validation failed")
+)
+
+// Is reports whether the API error is of the kind of the given sentinel error.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.HTTPStatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.HTTPStatusCode == http.StatusConflict
+	case ErrUnauthorized:
+		return e.HTTPStatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.HTTPStatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.HTTPStatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.HTTPStatusCode == http.StatusBadRequest || e.HTTPStatusCode == http.StatusUnprocessableEntity
+	}
+	return false
+}
+
+// FieldError is a validation error of a single field of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors decodes the field level validation errors from the details of the API error. Details are expected
+// either as a list of errors (optionally wrapped in an "errors" field), or as an object mapping fields to one or
+// more messages. Nil is returned if the details have no such structure.
+func (e *APIError) ValidationErrors() []FieldError {
+	if len(e.Details) == 0 {
+		return nil
+	}
+
+	var wrapped struct {
+		Errors json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(e.Details, &wrapped); err == nil && len(wrapped.Errors) > 0 {
+		return decodeFieldErrors(wrapped.Errors)
+	}
+
+	return decodeFieldErrors(e.Details)
+}
+
+func decodeFieldErrors(data json.RawMessage) []FieldError {
+	var list []struct {
+		FieldError
+		Path     string `json:"path"`
+		Property string `json:"property"`
+	}
+	if err := json.Unmarshal(data, &list); err == nil {
+		var fieldErrors []FieldError
+		for _, item := range list {
+			fieldError := item.FieldError
+			if fieldError.Field == "" {
+				fieldError.Field = item.Path
+			}
+			if fieldError.Field == "" {
+				fieldError.Field = item.Property
+			}
+			fieldErrors = append(fieldErrors, fieldError)
+		}
+		return fieldErrors
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fieldErrors []FieldError
+	for _, name := range names {
+		var message string
+		if err := json.Unmarshal(fields[name], &message); err == nil {
+			fieldErrors = append(fieldErrors, FieldError{Field: name, Message: message})
+			continue
+		}
+
+		var messages []string
+		if err := json.Unmarshal(fields[name], &messages); err != nil {
+			return nil
+		}
+		for _, message := range messages {
+			fieldErrors = append(fieldErrors, FieldError{Field: name, Message: message})
+		}
+	}
+
+	return fieldErrors
+}
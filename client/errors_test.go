@@ -0,0 +1,97 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	sentinels := map[int]error{
+		400: client.ErrValidation,
+		401: client.ErrUnauthorized,
+		403: client.ErrForbidden,
+		404: client.ErrNotFound,
+		409: client.ErrConflict,
+		422: client.ErrValidation,
+		429: client.ErrRateLimited,
+	}
+	all := []error{client.ErrValidation, client.ErrUnauthorized, client.ErrForbidden, client.ErrNotFound, client.ErrConflict, client.ErrRateLimited}
+
+	for status, sentinel := range sentinels {
+		err := &client.APIError{HTTPStatusCode: status}
+		for _, other := range all {
+			assert.Equal(t, other == sentinel, errors.Is(err, other), "status %d, error %v", status, other)
+		}
+	}
+
+	err := &client.APIError{HTTPStatusCode: 500}
+	for _, other := range all {
+		assert.False(t, errors.Is(err, other))
+	}
+}
+
+func TestAPIErrorIsWrapped(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseStatus: 429, ResponseBody: `{"error": "slow down"}`},
+		testutils.Call{ResponseStatus: 404, ResponseBody: `{"error": "not found"}`},
+	)
+
+	c, err := client.New("some-access-token",
+		client.WithHTTPClient(httpClient),
+		client.WithRetry(client.RetryPolicy{MaxAttempts: 2}))
+	require.NoError(t, err)
+
+	_, err = c.Sources.Get(context.Background(), "some-id")
+	assert.True(t, errors.Is(err, client.ErrNotFound))
+	assert.False(t, errors.Is(err, client.ErrRateLimited))
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestAPIErrorValidationErrors(t *testing.T) {
+	tests := []struct {
+		details  string
+		expected []client.FieldError
+	}{
+		{
+			details: `[{"field": "name", "message": "name is required"}, {"path": "config.host", "message": "invalid host", "code": "format"}]`,
+			expected: []client.FieldError{
+				{Field: "name", Message: "name is required"},
+				{Field: "config.host", Message: "invalid host", Code: "format"},
+			},
+		},
+		{
+			details:  `{"errors": [{"property": "type", "message": "unknown type"}]}`,
+			expected: []client.FieldError{{Field: "type", Message: "unknown type"}},
+		},
+		{
+			details: `{"type": "unknown type", "name": ["too short", "invalid characters"]}`,
+			expected: []client.FieldError{
+				{Field: "name", Message: "too short"},
+				{Field: "name", Message: "invalid characters"},
+				{Field: "type", Message: "unknown type"},
+			},
+		},
+		{
+			details:  `"some details"`,
+			expected: nil,
+		},
+		{
+			details:  ``,
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		err := &client.APIError{HTTPStatusCode: 400, Details: json.RawMessage(test.details)}
+		assert.Equal(t, test.expected, err.ValidationErrors(), test.details)
+	}
+
+	assert.Equal(t, "name: name is required", client.FieldError{Field: "name", Message: "name is required"}.Error())
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type operationKey struct{}
+
+// operationFromContext returns the name of the operation performed by a service, e.g. "sources.Create",
+// or an empty string for direct calls of Client.Do.
+func operationFromContext(ctx context.Context) string {
+	operation, _ := ctx.Value(operationKey{}).(string)
+	return operation
+}
+
+// CallInfo describes a call of Client.Do, which might span several attempts if retries are enabled.
+type CallInfo struct {
+	// Operation is the name of the service operation, e.g. "sources.Create", or empty for direct calls of Client.Do.
+	Operation string
+	Method    string
+	Path      string
+	// Attempts is the number of attempts made so far.
+	Attempts int
+	// StatusCode is the status code of the last response, or zero if no response was received.
+	StatusCode int
+}
+
+// RequestInfo describes an attempt of a request sent by Client.Do. The Authorization header and the values
+// of secret fields (e.g. passwords, tokens and keys) are redacted from Header and Body.
+type RequestInfo struct {
+	Operation string
+	Method    string
+	// Path is the path given to Client.Do, URL is the complete URL.
+	Path string
+	URL  string
+	// Attempt is the number of the attempt, starting at 1. It is greater than 1 for retries.
+	Attempt int
+	Header  http.Header
+	Body    []byte
+
+	header http.Header
+}
+
+// SetHeader sets a header of the outgoing request, e.g. to propagate a trace context.
+func (r *RequestInfo) SetHeader(key, value string) {
+	r.header.Set(key, value)
+	r.Header.Set(key, value)
+}
+
+// ResponseInfo describes the outcome of an attempt. Secrets are redacted from Body.
+type ResponseInfo struct {
+	Request *RequestInfo
+	// StatusCode is zero if no response was received.
+	StatusCode int
+	// ErrorCode is the error code of the API error, if any.
+	ErrorCode string
+	Latency   time.Duration
+	Header    http.Header
+	Body      []byte
+	Err       error
+}
+
+// Hook observes the requests sent by a client. Hooks are called synchronously, for every attempt of a request.
+type Hook interface {
+	BeforeRequest(ctx context.Context, req *RequestInfo)
+	AfterResponse(ctx context.Context, res *ResponseInfo)
+}
+
+// CallHook is an optional interface for hooks which observe whole calls of Client.Do, rather than single attempts.
+// The context returned by StartCall is used for all the attempts of the call, and is passed to EndCall.
+type CallHook interface {
+	StartCall(ctx context.Context, call *CallInfo) context.Context
+	EndCall(ctx context.Context, call *CallInfo, duration time.Duration, err error)
+}
+
+// HookFuncs adapts functions to the Hook interface. Nil functions are ignored.
+type HookFuncs struct {
+	OnRequest  func(ctx context.Context, req *RequestInfo)
+	OnResponse func(ctx context.Context, res *ResponseInfo)
+}
+
+func (h HookFuncs) BeforeRequest(ctx context.Context, req *RequestInfo) {
+	if h.OnRequest != nil {
+		h.OnRequest(ctx, req)
+	}
+}
+
+func (h HookFuncs) AfterResponse(ctx context.Context, res *ResponseInfo) {
+	if h.OnResponse != nil {
+		h.OnResponse(ctx, res)
+	}
+}
+
+// Logger is the logging interface used by WithLogger. It is implemented by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// loggerHook logs a line per attempt, once its outcome is known.
+type loggerHook struct {
+	logger Logger
+}
+
+func (h *loggerHook) BeforeRequest(ctx context.Context, req *RequestInfo) {}
+
+func (h *loggerHook) AfterResponse(ctx context.Context, res *ResponseInfo) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "rudder-api: method=%s path=%s attempt=%d latency=%s", res.Request.Method, res.Request.Path, res.Request.Attempt, res.Latency)
+	if res.StatusCode != 0 {
+		fmt.Fprintf(&b, " status=%d", res.StatusCode)
+	}
+	if res.ErrorCode != "" {
+		fmt.Fprintf(&b, " error_code=%s", res.ErrorCode)
+	}
+	if res.Err != nil {
+		fmt.Fprintf(&b, " error=%q", res.Err.Error())
+	}
+
+	h.logger.Printf("%s", b.String())
+}
+
+func newResponseInfo(req *RequestInfo, statusCode int, header http.Header, body []byte, latency time.Duration, err error) *ResponseInfo {
+	res := &ResponseInfo{
+		Request:    req,
+		StatusCode: statusCode,
+		Latency:    latency,
+		Header:     header,
+		Body:       body,
+		Err:        err,
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		res.ErrorCode = apiErr.ErrorCode
+	}
+
+	return res
+}
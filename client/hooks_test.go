@@ -0,0 +1,147 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientHook(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseStatus: 503, ResponseBody: `{"error": "unavailable", "code": "service_unavailable"}`},
+		testutils.Call{
+			ResponseStatus: 200,
+			ResponseHeader: http.Header{"X-Request-Id": []string{"some-request-id"}},
+			ResponseBody:   `{"source": {"id": "some-id", "writeKey": "some-write-key"}}`,
+		},
+	)
+
+	var requests []*client.RequestInfo
+	var responses []*client.ResponseInfo
+	c, err := client.New("some-access-token",
+		client.WithBaseURL("https://example.com"),
+		client.WithHTTPClient(httpClient),
+		client.WithRetry(client.RetryPolicy{MaxAttempts: 2, RetryNonIdempotent: true}),
+		client.WithHook(client.HookFuncs{
+			OnRequest:  func(ctx context.Context, req *client.RequestInfo) { requests = append(requests, req) },
+			OnResponse: func(ctx context.Context, res *client.ResponseInfo) { responses = append(responses, res) },
+		}))
+	require.NoError(t, err)
+
+	_, err = c.Do(context.Background(), "POST", "sources", bytes.NewReader([]byte(`{"name": "some-name", "config": {"password": "some-password"}}`)))
+	require.NoError(t, err)
+
+	require.Len(t, requests, 2)
+	for i, req := range requests {
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "sources", req.Path)
+		assert.Equal(t, "https://example.com/sources", req.URL)
+		assert.Equal(t, i+1, req.Attempt)
+		assert.Equal(t, "[REDACTED]", req.Header.Get("Authorization"))
+		assert.JSONEq(t, `{"name": "some-name", "config": {"password": "[REDACTED]"}}`, string(req.Body))
+	}
+
+	require.Len(t, responses, 2)
+	assert.Equal(t, requests[0], responses[0].Request)
+	assert.Equal(t, 503, responses[0].StatusCode)
+	assert.Equal(t, "service_unavailable", responses[0].ErrorCode)
+	assert.Error(t, responses[0].Err)
+
+	assert.Equal(t, 200, responses[1].StatusCode)
+	assert.Equal(t, "some-request-id", responses[1].Header.Get("X-Request-Id"))
+	assert.JSONEq(t, `{"source": {"id": "some-id", "writeKey": "[REDACTED]"}}`, string(responses[1].Body))
+	assert.NoError(t, responses[1].Err)
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientLogger(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseStatus: 200, ResponseBody: `{}`},
+		testutils.Call{ResponseStatus: 404, ResponseBody: `{"error": "not found", "code": "not_found"}`},
+		testutils.Call{ResponseError: fmt.Errorf("connection refused")},
+	)
+
+	var buf bytes.Buffer
+	c, err := client.New("some-access-token",
+		client.WithHTTPClient(httpClient),
+		client.WithLogger(log.New(&buf, "", 0)))
+	require.NoError(t, err)
+
+	_, _ = c.Do(context.Background(), "GET", "sources", nil)
+	_, _ = c.Do(context.Background(), "GET", "sources/some-id", nil)
+	_, _ = c.Do(context.Background(), "DELETE", "sources/some-id", nil)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+	assert.Regexp(t, `^rudder-api: method=GET path=sources attempt=1 latency=\S+ status=200$`, string(lines[0]))
+	assert.Regexp(t, `^rudder-api: method=GET path=sources/some-id attempt=1 latency=\S+ status=404 error_code=not_found error=".*not found.*"$`, string(lines[1]))
+	assert.Regexp(t, `^rudder-api: method=DELETE path=sources/some-id attempt=1 latency=\S+ error="connection refused"$`, string(lines[2]))
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientOptionHookNil(t *testing.T) {
+	_, err := client.New("some-access-token", client.WithHook(nil))
+	assert.Equal(t, client.ErrInvalidHook, err)
+
+	_, err = client.New("some-access-token", client.WithLogger(nil))
+	assert.Equal(t, client.ErrInvalidLogger, err)
+}
+
+type callHookKey struct{}
+
+type callHook struct {
+	client.HookFuncs
+	calls []client.CallInfo
+	errs  []error
+}
+
+func (h *callHook) StartCall(ctx context.Context, call *client.CallInfo) context.Context {
+	return context.WithValue(ctx, callHookKey{}, call.Operation)
+}
+
+func (h *callHook) EndCall(ctx context.Context, call *client.CallInfo, duration time.Duration, err error) {
+	h.calls = append(h.calls, *call)
+	h.errs = append(h.errs, err)
+}
+
+func TestClientCallHook(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{
+			Validate: func(req *http.Request) bool {
+				return assert.Equal(t, "sources.Get", req.Header.Get("X-Operation"))
+			},
+			ResponseStatus: 502,
+		},
+		testutils.Call{ResponseStatus: 200, ResponseBody: `{"source": {"id": "some-id"}}`},
+	)
+
+	hook := &callHook{}
+	hook.OnRequest = func(ctx context.Context, req *client.RequestInfo) {
+		assert.Equal(t, "sources.Get", ctx.Value(callHookKey{}))
+		assert.Equal(t, "sources.Get", req.Operation)
+		req.SetHeader("X-Operation", req.Operation)
+	}
+
+	c, err := client.New("some-access-token",
+		client.WithHTTPClient(httpClient),
+		client.WithRetry(client.RetryPolicy{MaxAttempts: 2}),
+		client.WithHook(hook))
+	require.NoError(t, err)
+
+	_, err = c.Sources.Get(context.Background(), "some-id")
+	require.NoError(t, err)
+
+	require.Len(t, hook.calls, 1)
+	assert.Equal(t, client.CallInfo{Operation: "sources.Get", Method: "GET", Path: "sources/some-id", Attempts: 2, StatusCode: 200}, hook.calls[0])
+	assert.NoError(t, hook.errs[0])
+	httpClient.AssertNumberOfCalls()
+}
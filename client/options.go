@@ -28,3 +28,89 @@ func WithUserAgent(userAgent string) Option {
 		return nil
 	}
 }
+
+// WithRetry enables retrying failed requests according to the given policy.
+// Use DefaultRetryPolicy for sensible defaults.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) error {
+		if policy.MaxAttempts < 1 {
+			return ErrInvalidRetryPolicy
+		}
+		c.retryPolicy = &policy
+		return nil
+	}
+}
+
+// WithRateLimit limits the rate of requests sent by the client, and therefore by all of its services,
+// to requestsPerSecond on average, allowing bursts of up to burst requests. The limiter also slows down
+// when the API reports an exhausted quota through the X-RateLimit-Remaining and X-RateLimit-Reset headers.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) error {
+		if requestsPerSecond <= 0 || burst < 1 {
+			return ErrInvalidRateLimit
+		}
+		c.rateLimiter = newRateLimiter(requestsPerSecond, burst)
+		return nil
+	}
+}
+
+// WithHook registers a hook observing every attempt of every request sent by the client.
+// It can be used multiple times to register several hooks, which are called in order.
+func WithHook(hook Hook) Option {
+	return func(c *Client) error {
+		if hook == nil {
+			return ErrInvalidHook
+		}
+		c.hooks = append(c.hooks, hook)
+		return nil
+	}
+}
+
+// WithLogger logs a line for every attempt of every request sent by the client, with its method, path,
+// attempt number, latency, status and error. The logger can be a *log.Logger.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) error {
+		if logger == nil {
+			return ErrInvalidLogger
+		}
+		c.hooks = append(c.hooks, &loggerHook{logger: logger})
+		return nil
+	}
+}
+
+// WithConfigValidation validates the configs of sources and destinations against the JSON Schema of their type before
+// they are created or updated, returning every violation at once as a *ConfigValidationError. Schemas can be the ones
+// shipped with this package, with BundledSchemas, or any other SchemaProvider; see WithDefinitionsConfigValidation
+// to fetch them from the API.
+func WithConfigValidation(provider SchemaProvider) Option {
+	return func(c *Client) error {
+		if provider == nil {
+			return ErrInvalidSchemaProvider
+		}
+
+		c.configValidator = newConfigValidator(provider)
+		return nil
+	}
+}
+
+// WithDefinitionsConfigValidation is like WithConfigValidation, with the schemas of the source and destination
+// definitions fetched from the API. The schema of a type is fetched once, the first time it is needed.
+func WithDefinitionsConfigValidation() Option {
+	return func(c *Client) error {
+		c.configValidator = newConfigValidator(c.Definitions)
+		return nil
+	}
+}
+
+// WithDryRun puts every service of the client in dry run mode: Create, Update, Delete and the other mutating calls
+// record their method, path and JSON body in recorder, send nothing and return a result synthesized from their input.
+// Reads are still sent. See ContextWithDryRun to enable the mode for some calls only.
+func WithDryRun(recorder *DryRun) Option {
+	return func(c *Client) error {
+		if recorder == nil {
+			return ErrInvalidDryRun
+		}
+		c.dryRunRecorder = recorder
+		return nil
+	}
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidRateLimit = fmt.Errorf("rate limit must be positive and allow a burst of at least one request")
+	ErrRateLimitWait    = fmt.Errorf("rate limit wait would exceed context deadline")
+)
+
+// rateLimiter is a token bucket limiter, shared by every request performed through a Client.
+// Besides its configured rate, it slows down when the API reports that the quota is exhausted.
+type rateLimiter struct {
+	mu           sync.Mutex
+	rate         float64 // tokens per second
+	burst        float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a request is allowed to be sent, or until the context is done. If the context has
+// a deadline which is earlier than the time the request would be allowed, wait returns immediately.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.refill(now)
+
+	// reserve a token, possibly going into debt, so that concurrent waiters are served in order
+	l.tokens--
+	at := now
+	if l.tokens < 0 {
+		at = now.Add(time.Duration(-l.tokens / l.rate * float64(time.Second)))
+	}
+	if at.Before(l.blockedUntil) {
+		at = l.blockedUntil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && at.After(deadline) {
+		l.tokens++
+		l.mu.Unlock()
+		return ErrRateLimitWait
+	}
+	l.mu.Unlock()
+
+	if err := sleep(ctx, at.Sub(now)); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+func (l *rateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += elapsed.Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+}
+
+// observe adapts the limiter to the rate limit headers of an API response.
+func (l *rateLimiter) observe(statusCode int, header http.Header) {
+	now := time.Now()
+
+	var until time.Time
+	if statusCode == http.StatusTooManyRequests {
+		if wait, ok := parseRetryAfter(header.Get("Retry-After"), now); ok {
+			until = now.Add(wait)
+		}
+	}
+
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	hasRemaining := err == nil && remaining >= 0
+	if hasRemaining && remaining == 0 {
+		if reset, ok := parseRateLimitReset(header.Get("X-RateLimit-Reset"), now); ok && reset.After(until) {
+			until = reset
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(now)
+	if hasRemaining && float64(remaining) < l.tokens {
+		l.tokens = float64(remaining)
+	}
+	if until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// parseRateLimitReset parses X-RateLimit-Reset, which is either a number of seconds
+// until the quota resets or the unix timestamp at which it resets.
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}, false
+	}
+
+	// values larger than a year are considered to be unix timestamps
+	if seconds > 365*24*60*60 {
+		return time.Unix(seconds, 0), true
+	}
+
+	return now.Add(time.Duration(seconds) * time.Second), true
+}
@@ -0,0 +1,95 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRateLimit(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseStatus: 200, ResponseBody: `{}`},
+		testutils.Call{ResponseStatus: 200, ResponseBody: `{}`},
+		testutils.Call{ResponseStatus: 200, ResponseBody: `{}`},
+	)
+
+	c, err := client.New("some-access-token",
+		client.WithHTTPClient(httpClient),
+		client.WithRateLimit(20, 2))
+	require.NoError(t, err)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := c.Do(context.Background(), "GET", "path", nil)
+		require.NoError(t, err)
+	}
+
+	// the first two requests use the burst, the third one has to wait for a token
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(40*time.Millisecond))
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientRateLimitDeadline(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseStatus: 200, ResponseBody: `{}`},
+	)
+
+	c, err := client.New("some-access-token",
+		client.WithHTTPClient(httpClient),
+		client.WithRateLimit(0.1, 1))
+	require.NoError(t, err)
+
+	_, err = c.Do(context.Background(), "GET", "path", nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// the next token is only available in 10 seconds, after the context deadline
+	_, err = c.Do(ctx, "GET", "path", nil)
+	assert.Equal(t, client.ErrRateLimitWait, err)
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientRateLimitHeaders(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{
+			ResponseStatus: 200,
+			ResponseHeader: http.Header{
+				"X-Ratelimit-Remaining": []string{"0"},
+				"X-Ratelimit-Reset":     []string{"3600"},
+			},
+			ResponseBody: `{}`,
+		},
+	)
+
+	c, err := client.New("some-access-token",
+		client.WithHTTPClient(httpClient),
+		client.WithRateLimit(100, 10))
+	require.NoError(t, err)
+
+	_, err = c.Do(context.Background(), "GET", "path", nil)
+	require.NoError(t, err)
+
+	// the API reported an exhausted quota, so the limiter blocks despite having tokens left
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err = c.Do(ctx, "GET", "path", nil)
+	assert.Equal(t, client.ErrRateLimitWait, err)
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientOptionRateLimitInvalid(t *testing.T) {
+	_, err := client.New("some-access-token", client.WithRateLimit(0, 1))
+	assert.Equal(t, client.ErrInvalidRateLimit, err)
+
+	_, err = client.New("some-access-token", client.WithRateLimit(1, 0))
+	assert.Equal(t, client.ErrInvalidRateLimit, err)
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Types of regulations
+const (
+	// RegulationSuppress drops the incoming events of the users.
+	RegulationSuppress = "suppress"
+	// RegulationSuppressWithDelete drops the incoming events of the users and deletes their data from the destinations.
+	RegulationSuppressWithDelete = "suppress_with_delete"
+	// RegulationDelete deletes the data of the users from the destinations.
+	RegulationDelete = "delete"
+)
+
+// Statuses of regulations
+const (
+	RegulationPending   = "pending"
+	RegulationRunning   = "running"
+	RegulationCompleted = "completed"
+	RegulationFailed    = "failed"
+	RegulationCancelled = "cancelled"
+)
+
+// MaxRegulationUsers is the maximum number of users of a single regulation accepted by the API.
+const MaxRegulationUsers = 1000
+
+var ErrNoRegulationUsers = fmt.Errorf("regulation must have at least one user")
+
+// Regulation is a request to suppress the events of users, or to delete their data. It applies to the whole workspace,
+// unless it is scoped to some sources or destinations.
+type Regulation struct {
+	ID             string     `json:"id,omitempty"`
+	Type           string     `json:"regulationType"`
+	UserIDs        []string   `json:"userIds"`
+	SourceIDs      []string   `json:"sourceIds,omitempty"`
+	DestinationIDs []string   `json:"destinationIds,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	CreatedAt      *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt      *time.Time `json:"updatedAt,omitempty"`
+}
+
+type regulations struct {
+	*service
+}
+
+type RegulationsPage struct {
+	APIPage
+	Regulations []Regulation `json:"regulations"`
+}
+
+func (s *regulations) Next(ctx context.Context, paging Paging) (*RegulationsPage, error) {
+	page := &RegulationsPage{}
+	ok, err := s.service.next(ctx, paging, page)
+	if !ok {
+		page = nil
+	}
+	return page, err
+}
+
+func (s *regulations) List(ctx context.Context) (*RegulationsPage, error) {
+	page := &RegulationsPage{}
+	if err := s.list(ctx, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// RegulationsIterator iterates over all regulations, fetching pages on demand.
+type RegulationsIterator struct {
+	pager
+	regulations []Regulation
+	index       int
+}
+
+// All returns an iterator over all regulations. Pages are fetched lazily, as the iterator advances.
+// Any error, including the cancellation of ctx, stops the iteration and is available through Err.
+func (s *regulations) All(ctx context.Context) *RegulationsIterator {
+	return &RegulationsIterator{pager: s.pager(ctx)}
+}
+
+// Next advances the iterator to the next regulation. It returns false when there are no more regulations or an error occurred.
+func (it *RegulationsIterator) Next() bool {
+	if it.stopped() {
+		return false
+	}
+
+	for it.index >= len(it.regulations) {
+		page := &RegulationsPage{}
+		if !it.fetch(page, &page.APIPage) {
+			return false
+		}
+		it.regulations, it.index = page.Regulations, 0
+	}
+
+	it.index++
+	return true
+}
+
+// Regulation returns the current regulation.
+func (it *RegulationsIterator) Regulation() Regulation {
+	return it.regulations[it.index-1]
+}
+
+// Get returns a regulation, with its current status.
+func (s *regulations) Get(ctx context.Context, id string) (*Regulation, error) {
+	response := struct{ Regulation *Regulation }{}
+	if err := s.get(ctx, id, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Regulation, nil
+}
+
+// Create creates a regulation for at most MaxRegulationUsers users, see CreateInBatches for larger lists of users.
+func (s *regulations) Create(ctx context.Context, regulation *Regulation) (*Regulation, error) {
+	// copy input and remove fields that should not be in request body without modifying input
+	r := *regulation
+	r.ID = ""
+	r.Status = ""
+
+	response := struct{ Regulation *Regulation }{}
+	if err := s.create(ctx, &r, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Regulation, nil
+}
+
+// CreateInBatches creates as many regulations as needed for all the users of regulation, with at most batchSize users
+// each. A batchSize lower than 1 or greater than MaxRegulationUsers defaults to MaxRegulationUsers. If a regulation
+// cannot be created, the ones created so far are returned along with the error. ErrNoRegulationUsers is returned if
+// regulation has no users.
+func (s *regulations) CreateInBatches(ctx context.Context, regulation *Regulation, batchSize int) ([]Regulation, error) {
+	if len(regulation.UserIDs) == 0 {
+		return nil, ErrNoRegulationUsers
+	}
+
+	if batchSize < 1 || batchSize > MaxRegulationUsers {
+		batchSize = MaxRegulationUsers
+	}
+
+	var created []Regulation
+	for start := 0; start < len(regulation.UserIDs); start += batchSize {
+		end := start + batchSize
+		if end > len(regulation.UserIDs) {
+			end = len(regulation.UserIDs)
+		}
+
+		batch := *regulation
+		batch.UserIDs = regulation.UserIDs[start:end]
+		r, err := s.Create(ctx, &batch)
+		if err != nil {
+			return created, fmt.Errorf("creating regulation for users %d to %d: %w", start, end-1, err)
+		}
+		created = append(created, *r)
+	}
+
+	return created, nil
+}
+
+// Cancel cancels a regulation which is not completed yet.
+func (s *regulations) Cancel(ctx context.Context, id string) (*Regulation, error) {
+	response := struct{ Regulation *Regulation }{}
+	if err := s.action(ctx, "Cancel", "POST", []string{id, "cancel"}, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Regulation, nil
+}
@@ -0,0 +1,153 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRegulations(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/regulations", `{
+					"regulationType": "suppress",
+					"userIds": ["user-1", "user-2"],
+					"sourceIds": ["source-1"]
+				}`)
+			},
+			ResponseStatus: 201,
+			ResponseBody: `{ "regulation": {
+				"id": "some-id",
+				"regulationType": "suppress",
+				"userIds": ["user-1", "user-2"],
+				"sourceIds": ["source-1"],
+				"status": "pending"
+			} }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/regulations/some-id", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "regulation": { "id": "some-id", "status": "running" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/regulations/some-id/cancel", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "regulation": { "id": "some-id", "status": "cancelled" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/regulations", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"regulations": [{ "id": "some-id", "regulationType": "suppress", "status": "cancelled" }],
+				"paging": { "total": 2, "next": "/regulations?page=2" }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/regulations?page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"regulations": [{ "id": "other-id", "regulationType": "delete", "status": "completed" }],
+				"paging": { "total": 2 }
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	regulation, err := c.Regulations.Create(ctx, &client.Regulation{
+		Type:      client.RegulationSuppress,
+		UserIDs:   []string{"user-1", "user-2"},
+		SourceIDs: []string{"source-1"},
+		Status:    client.RegulationCompleted,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "some-id", regulation.ID)
+	assert.Equal(t, client.RegulationPending, regulation.Status)
+
+	regulation, err = c.Regulations.Get(ctx, "some-id")
+	require.NoError(t, err)
+	assert.Equal(t, client.RegulationRunning, regulation.Status)
+
+	regulation, err = c.Regulations.Cancel(ctx, "some-id")
+	require.NoError(t, err)
+	assert.Equal(t, client.RegulationCancelled, regulation.Status)
+
+	page, err := c.Regulations.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []client.Regulation{{ID: "some-id", Type: client.RegulationSuppress, Status: client.RegulationCancelled}}, page.Regulations)
+
+	page, err = c.Regulations.Next(ctx, page.Paging)
+	require.NoError(t, err)
+	assert.Equal(t, []client.Regulation{{ID: "other-id", Type: client.RegulationDelete, Status: client.RegulationCompleted}}, page.Regulations)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientRegulationsCreateInBatches(t *testing.T) {
+	ctx := context.Background()
+
+	batch := func(users string, status int, body string) testutils.Call {
+		return testutils.Call{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/regulations",
+					fmt.Sprintf(`{ "regulationType": "delete", "userIds": %s }`, users))
+			},
+			ResponseStatus: status,
+			ResponseBody:   body,
+		}
+	}
+
+	calls := []testutils.Call{
+		batch(`["user-1", "user-2"]`, 201, `{ "regulation": { "id": "id-1" } }`),
+		batch(`["user-3", "user-4"]`, 201, `{ "regulation": { "id": "id-2" } }`),
+		batch(`["user-5"]`, 400, `{ "error": "invalid user id", "code": "validation_error" }`),
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	regulations, err := c.Regulations.CreateInBatches(ctx, &client.Regulation{
+		Type:    client.RegulationDelete,
+		UserIDs: []string{"user-1", "user-2", "user-3", "user-4", "user-5"},
+	}, 2)
+	assert.ErrorIs(t, err, client.ErrValidation)
+	assert.Contains(t, err.Error(), "users 4 to 4")
+	assert.Equal(t, []client.Regulation{{ID: "id-1"}, {ID: "id-2"}}, regulations)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientRegulationsCreateInBatchesNoUsers(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	regulations, err := c.Regulations.CreateInBatches(context.Background(), &client.Regulation{Type: client.RegulationDelete}, 2)
+	assert.ErrorIs(t, err, client.ErrNoRegulationUsers)
+	assert.Nil(t, regulations)
+
+	httpClient.AssertNumberOfCalls()
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Response holds the metadata of an API response, as recorded by CaptureResponse.
+type Response struct {
+	Method     string
+	URL        string
+	StatusCode int
+	// RequestID is the ID the API assigned to the request, if any. It should be included in support tickets.
+	RequestID string
+	Header    http.Header
+	Body      []byte
+}
+
+type responseKey struct{}
+
+// CaptureResponse returns a context which records the metadata of the responses received by calls made with it,
+// such as Sources.Create, into res. If a call makes several attempts, the last response is kept. Calls which
+// fail before receiving any response leave res untouched.
+func CaptureResponse(ctx context.Context, res *Response) context.Context {
+	return context.WithValue(ctx, responseKey{}, res)
+}
+
+func capturedResponse(ctx context.Context) *Response {
+	res, _ := ctx.Value(responseKey{}).(*Response)
+	return res
+}
+
+// requestID returns the request ID of a response, from the X-Request-Id or Request-Id header.
+func requestID(header http.Header) string {
+	if id := header.Get("X-Request-Id"); id != "" {
+		return id
+	}
+	return header.Get("Request-Id")
+}
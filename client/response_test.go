@@ -0,0 +1,83 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCaptureResponse(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t, testutils.Call{
+		ResponseStatus: 200,
+		ResponseHeader: http.Header{"X-Request-Id": []string{"some-request-id"}},
+		ResponseBody:   `{"source": {"id": "some-id"}}`,
+	})
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	var res client.Response
+	source, err := c.Sources.Get(client.CaptureResponse(context.Background(), &res), "some-id")
+	require.NoError(t, err)
+	assert.Equal(t, "some-id", source.ID)
+
+	assert.Equal(t, "GET", res.Method)
+	assert.Equal(t, "https://api.rudderstack.com/v2/sources/some-id", res.URL)
+	assert.Equal(t, 200, res.StatusCode)
+	assert.Equal(t, "some-request-id", res.RequestID)
+	assert.Equal(t, "some-request-id", res.Header.Get("X-Request-Id"))
+	assert.Equal(t, `{"source": {"id": "some-id"}}`, string(res.Body))
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientAPIErrorMetadata(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t, testutils.Call{
+		ResponseStatus: 409,
+		ResponseHeader: http.Header{"X-Request-Id": []string{"some-request-id"}},
+		ResponseBody:   `{"error": "already exists", "code": "conflict"}`,
+	})
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	err = c.Connections.Delete(context.Background(), "some-id")
+	apiErr, ok := err.(*client.APIError)
+	require.True(t, ok)
+	assert.Equal(t, 409, apiErr.HTTPStatusCode)
+	assert.Equal(t, "already exists", apiErr.Message)
+	assert.Equal(t, "conflict", apiErr.ErrorCode)
+	assert.Equal(t, "DELETE", apiErr.Method)
+	assert.Equal(t, "https://api.rudderstack.com/v2/connections/some-id", apiErr.URL)
+	assert.Equal(t, "some-request-id", apiErr.RequestID)
+	assert.Equal(t, "some-request-id", apiErr.Header.Get("X-Request-Id"))
+	assert.Equal(t, `{"error": "already exists", "code": "conflict"}`, string(apiErr.Body))
+	assert.Equal(t, "http status code: 409, error code: 'conflict', error: 'already exists', request id: 'some-request-id'", apiErr.Error())
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientAPIErrorUnparseableBody(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t, testutils.Call{
+		ResponseStatus: 502,
+		ResponseBody:   `<html>Bad Gateway</html>`,
+	})
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	var res client.Response
+	_, err = c.Do(client.CaptureResponse(context.Background(), &res), "GET", "path", nil)
+	apiErr, ok := err.(*client.APIError)
+	require.True(t, ok)
+	assert.Equal(t, 502, apiErr.HTTPStatusCode)
+	assert.Equal(t, "Bad Gateway", apiErr.Message)
+	assert.Equal(t, `<html>Bad Gateway</html>`, string(apiErr.Body))
+
+	// responses are captured for errors too
+	assert.Equal(t, 502, res.StatusCode)
+	httpClient.AssertNumberOfCalls()
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how Client.Do retries requests that failed with a transport error
+// or with a transient HTTP status code (429, 502, 503 and 504).
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts per request, including the first one.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. Every subsequent delay is doubled, up to MaxBackoff.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts, unless the API asks for a longer one with Retry-After.
+	MaxBackoff time.Duration
+	// RetryNonIdempotent allows retrying POST and PATCH requests. These are never retried by default,
+	// as replaying them might create duplicate resources.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is a reasonable retry policy for most use cases.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+var ErrInvalidRetryPolicy = fmt.Errorf("retry policy must allow at least one attempt")
+
+// RetryError is returned by Client.Do when a request has been attempted more than once and still failed.
+// Attempts holds the error of every attempt, in order; the last one might be the context error that
+// interrupted the retries.
+type RetryError struct {
+	Attempts []error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("request failed after %d attempts: %v", len(e.Attempts), e.Unwrap())
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RetryError) Unwrap() error {
+	if len(e.Attempts) == 0 {
+		return nil
+	}
+	return e.Attempts[len(e.Attempts)-1]
+}
+
+// APIErrors returns the API errors received during the attempts, in order.
+func (e *RetryError) APIErrors() []*APIError {
+	var apiErrors []*APIError
+	for _, err := range e.Attempts {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			apiErrors = append(apiErrors, apiErr)
+		}
+	}
+	return apiErrors
+}
+
+func (p *RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, err error) bool {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil || errors.Is(err, ErrRateLimitWait) {
+		return false
+	}
+
+	if !p.RetryNonIdempotent && (method == "POST" || method == "PATCH") {
+		return false
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		// transport error
+		return true
+	}
+
+	switch apiErr.HTTPStatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// backoff returns the delay before the given retry attempt, honouring the Retry-After header if present.
+func (p *RetryPolicy) backoff(attempt int, header http.Header) time.Duration {
+	if wait, ok := parseRetryAfter(header.Get("Retry-After"), time.Now()); ok {
+		return wait
+	}
+
+	wait := p.MinBackoff
+	for i := 1; i < attempt && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	// equal jitter: keep half of the delay and randomize the other half
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
+
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,169 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryPolicy = client.RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  time.Millisecond,
+}
+
+func TestClientRetryTransientErrors(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseStatus: 503, ResponseBody: `{"error": "unavailable", "code": "service_unavailable"}`},
+		testutils.Call{
+			ResponseStatus: 429,
+			ResponseHeader: http.Header{"Retry-After": []string{"0"}},
+			ResponseBody:   `{"error": "slow down", "code": "rate_limited"}`,
+		},
+		testutils.Call{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "PUT", "https://example.com/path", `{"key": "val"}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   "test",
+		},
+	)
+
+	c, err := client.New("some-access-token",
+		client.WithBaseURL("https://example.com"),
+		client.WithHTTPClient(httpClient),
+		client.WithRetry(testRetryPolicy))
+	require.NoError(t, err)
+
+	res, err := c.Do(context.Background(), "PUT", "path", bytes.NewReader([]byte(`{"key": "val"}`)))
+	require.NoError(t, err)
+	assert.Equal(t, "test", string(res))
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientRetryExhausted(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseError: fmt.Errorf("connection reset")},
+		testutils.Call{ResponseStatus: 502, ResponseBody: `{"error": "bad gateway", "code": "bad_gateway"}`},
+		testutils.Call{ResponseStatus: 503, ResponseBody: `{"error": "unavailable", "code": "service_unavailable"}`},
+	)
+
+	c, err := client.New("some-access-token",
+		client.WithHTTPClient(httpClient),
+		client.WithRetry(testRetryPolicy))
+	require.NoError(t, err)
+
+	_, err = c.Do(context.Background(), "GET", "path", nil)
+	require.Error(t, err)
+
+	var retryErr *client.RetryError
+	require.True(t, errors.As(err, &retryErr))
+	assert.Len(t, retryErr.Attempts, 3)
+
+	apiErrors := retryErr.APIErrors()
+	require.Len(t, apiErrors, 2)
+	assert.Equal(t, 502, apiErrors[0].HTTPStatusCode)
+	assert.Equal(t, 503, apiErrors[1].HTTPStatusCode)
+
+	// the last attempt's error is available through errors.As
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "service_unavailable", apiErr.ErrorCode)
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientRetryNonRetryableStatus(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseStatus: 404, ResponseBody: `{"error": "not found", "code": "not_found"}`},
+	)
+
+	c, err := client.New("some-access-token",
+		client.WithHTTPClient(httpClient),
+		client.WithRetry(testRetryPolicy))
+	require.NoError(t, err)
+
+	_, err = c.Do(context.Background(), "GET", "path", nil)
+	apiErr, ok := err.(*client.APIError)
+	require.True(t, ok)
+	assert.Equal(t, 404, apiErr.HTTPStatusCode)
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientRetryNonIdempotent(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseStatus: 503, ResponseBody: `{"error": "unavailable"}`},
+	)
+
+	c, err := client.New("some-access-token",
+		client.WithHTTPClient(httpClient),
+		client.WithRetry(testRetryPolicy))
+	require.NoError(t, err)
+
+	_, err = c.Do(context.Background(), "POST", "path", bytes.NewReader([]byte(`{}`)))
+	_, ok := err.(*client.APIError)
+	assert.True(t, ok)
+	httpClient.AssertNumberOfCalls()
+
+	policy := testRetryPolicy
+	policy.RetryNonIdempotent = true
+	httpClient = testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseStatus: 503, ResponseBody: `{"error": "unavailable"}`},
+		testutils.Call{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/path", `{"key": "val"}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   "test",
+		},
+	)
+
+	c, err = client.New("some-access-token",
+		client.WithHTTPClient(httpClient),
+		client.WithRetry(policy))
+	require.NoError(t, err)
+
+	res, err := c.Do(context.Background(), "POST", "path", bytes.NewReader([]byte(`{"key": "val"}`)))
+	require.NoError(t, err)
+	assert.Equal(t, "test", string(res))
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientRetryContextCancelled(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{
+			ResponseStatus: 429,
+			ResponseHeader: http.Header{"Retry-After": []string{"3600"}},
+			ResponseBody:   `{"error": "slow down"}`,
+		},
+	)
+
+	c, err := client.New("some-access-token",
+		client.WithHTTPClient(httpClient),
+		client.WithRetry(testRetryPolicy))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = c.Do(ctx, "GET", "path", nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	var retryErr *client.RetryError
+	require.True(t, errors.As(err, &retryErr))
+	assert.Len(t, retryErr.APIErrors(), 1)
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientOptionRetryInvalid(t *testing.T) {
+	_, err := client.New("some-access-token", client.WithRetry(client.RetryPolicy{}))
+	assert.Equal(t, client.ErrInvalidRetryPolicy, err)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"strings"
 )
 
@@ -12,12 +13,23 @@ type service struct {
 	client   *Client
 }
 
+// operation names the operation performed with the returned context, e.g. "sources.Create", or
+// "catalog.events.Create" for nested base paths.
+func (s *service) operation(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationKey{}, strings.ReplaceAll(s.basePath, "/", ".")+"."+name)
+}
+
 func (s *service) next(ctx context.Context, paging Paging, result interface{}) (bool, error) {
+	return s.nextPage(ctx, "List", paging, result)
+}
+
+// nextPage fetches the next page of any list operation of the service, e.g. the versions of a resource.
+func (s *service) nextPage(ctx context.Context, operation string, paging Paging, result interface{}) (bool, error) {
 	if paging.Next == "" {
 		return false, nil
 	}
 
-	res, err := s.client.Do(ctx, "GET", paging.Next, nil)
+	res, err := s.client.Do(s.operation(ctx, operation), "GET", paging.Next, nil)
 	if err != nil {
 		return false, err
 	}
@@ -35,7 +47,7 @@ func (s *service) list(ctx context.Context, result interface{}) error {
 }
 
 func (s *service) get(ctx context.Context, id string, result interface{}) error {
-	res, err := s.client.Do(ctx, "GET", strings.Join([]string{s.basePath, id}, "/"), nil)
+	res, err := s.client.Do(s.operation(ctx, "Get"), "GET", strings.Join([]string{s.basePath, id}, "/"), nil)
 	if err != nil {
 		return err
 	}
@@ -48,42 +60,105 @@ func (s *service) get(ctx context.Context, id string, result interface{}) error
 }
 
 func (s *service) create(ctx context.Context, input interface{}, result interface{}) error {
-	body, err := json.Marshal(input)
-	if err != nil {
-		return err
+	return s.send(ctx, "Create", "POST", s.basePath, "", input, result)
+}
+
+func (s *service) update(ctx context.Context, id string, input interface{}, result interface{}) error {
+	return s.send(ctx, "Update", "PUT", strings.Join([]string{s.basePath, id}, "/"), id, input, result)
+}
+
+func (s *service) delete(ctx context.Context, id string) error {
+	return s.send(ctx, "Delete", "DELETE", strings.Join([]string{s.basePath, id}, "/"), id, nil, nil)
+}
+
+// action performs an operation other than CRUD on the service, e.g. publishing a resource. The path is relative to
+// the base path of the service. The input, if not nil, is sent as JSON body, and the response is decoded into
+// result, if not nil.
+func (s *service) action(ctx context.Context, operation, method string, path []string, input interface{}, result interface{}) error {
+	return s.send(ctx, operation, method, strings.Join(append([]string{s.basePath}, path...), "/"), "", input, result)
+}
+
+// send sends a request with input, if not nil, as JSON body, and decodes the response into result, if not nil.
+// In dry run mode, mutating requests are recorded instead, and result is synthesized from input and id, created
+// resources getting a placeholder ID.
+func (s *service) send(ctx context.Context, operation, method, path, id string, input interface{}, result interface{}) error {
+	ctx = s.operation(ctx, operation)
+
+	var data []byte
+	if input != nil {
+		var err error
+		if data, err = json.Marshal(input); err != nil {
+			return err
+		}
 	}
 
-	res, err := s.client.Do(ctx, "POST", s.basePath, bytes.NewReader(body))
+	if recorder := s.client.dryRun(ctx); recorder != nil && method != "GET" {
+		recorder.record(DryRunCall{Operation: operationFromContext(ctx), Method: method, Path: path, Body: data})
+		if id == "" && operation == "Create" {
+			id = recorder.placeholderID()
+		}
+		return synthesize(result, data, id)
+	}
+
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+
+	res, err := s.client.Do(ctx, method, path, body)
 	if err != nil {
 		return err
 	}
 
-	if err = json.Unmarshal(res, result); err != nil {
-		return err
+	if result == nil {
+		return nil
 	}
 
-	return nil
+	return json.Unmarshal(res, result)
 }
 
-func (s *service) update(ctx context.Context, id string, input interface{}, result interface{}) error {
-	body, err := json.Marshal(input)
-	if err != nil {
-		return err
-	}
+// pager lazily fetches the pages of a list endpoint. It is embedded by the resource specific iterators.
+type pager struct {
+	ctx     context.Context
+	service *service
+	paging  Paging
+	err     error
+}
 
-	res, err := s.client.Do(ctx, "PUT", strings.Join([]string{s.basePath, id}, "/"), bytes.NewReader(body))
-	if err != nil {
-		return err
+func (s *service) pager(ctx context.Context) pager {
+	return s.pagerFrom(ctx, s.basePath)
+}
+
+// pagerFrom returns a pager starting at path, e.g. the base path with a query string filtering the results.
+func (s *service) pagerFrom(ctx context.Context, path string) pager {
+	return pager{ctx: ctx, service: s, paging: Paging{Next: path}}
+}
+
+// fetch retrieves the next page into result, returning false once there are no more pages or an error occurred.
+func (p *pager) fetch(result interface{}, page *APIPage) bool {
+	if p.err != nil || p.paging.Next == "" {
+		return false
 	}
 
-	if err = json.Unmarshal(res, result); err != nil {
-		return err
+	if _, err := p.service.next(p.ctx, p.paging, result); err != nil {
+		p.err = err
+		return false
 	}
 
-	return nil
+	p.paging = page.Paging
+	return true
 }
 
-func (s *service) delete(ctx context.Context, id string) error {
-	_, err := s.client.Do(ctx, "DELETE", strings.Join([]string{s.basePath, id}, "/"), nil)
-	return err
+// stopped reports whether the iteration must stop, either because of an earlier error or because
+// the context is done.
+func (p *pager) stopped() bool {
+	if p.err == nil {
+		p.err = p.ctx.Err()
+	}
+	return p.err != nil
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *pager) Err() error {
+	return p.err
 }
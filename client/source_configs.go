@@ -0,0 +1,136 @@
+package client
+
+import (
+	"sync"
+)
+
+// SourceConfig is implemented by the typed configurations of sources.
+type SourceConfig interface {
+	// SourceType returns the source type the configuration applies to, e.g. "HTTP".
+	SourceType() string
+}
+
+var (
+	sourceConfigsMu sync.RWMutex
+	sourceConfigs   = map[string]func() SourceConfig{
+		"HTTP":        func() SourceConfig { return &HTTPSourceConfig{} },
+		"Javascript":  sdkSourceConfig("Javascript"),
+		"Android":     sdkSourceConfig("Android"),
+		"iOS":         sdkSourceConfig("iOS"),
+		"ReactNative": sdkSourceConfig("ReactNative"),
+		"Flutter":     sdkSourceConfig("Flutter"),
+		"Stripe":      cloudSourceConfig("Stripe"),
+		"Salesforce":  cloudSourceConfig("Salesforce"),
+		"HubSpot":     cloudSourceConfig("HubSpot"),
+		"Zendesk":     cloudSourceConfig("Zendesk"),
+	}
+)
+
+func sdkSourceConfig(sourceType string) func() SourceConfig {
+	return func() SourceConfig { return &SDKSourceConfig{Type: sourceType} }
+}
+
+func cloudSourceConfig(sourceType string) func() SourceConfig {
+	return func() SourceConfig { return &CloudSourceConfig{Type: sourceType} }
+}
+
+// RegisterSourceConfig registers the typed configuration used by Source.DecodeConfig for the given
+// source type, replacing any existing one. The factory must return a pointer to a struct.
+func RegisterSourceConfig(sourceType string, factory func() SourceConfig) {
+	sourceConfigsMu.Lock()
+	defer sourceConfigsMu.Unlock()
+	sourceConfigs[sourceType] = factory
+}
+
+func newSourceConfig(sourceType string) SourceConfig {
+	sourceConfigsMu.RLock()
+	defer sourceConfigsMu.RUnlock()
+	if factory, ok := sourceConfigs[sourceType]; ok {
+		return factory()
+	}
+	return nil
+}
+
+// DecodeConfig decodes the source's configuration into the typed configuration registered for its type.
+// A *RawConfig is returned for types without a registered typed configuration.
+func (s *Source) DecodeConfig() (SourceConfig, error) {
+	config := newSourceConfig(s.Type)
+	if config == nil {
+		return &RawConfig{Type: s.Type, JSON: s.Config}, nil
+	}
+
+	if err := decodeConfig(s.Config, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// EncodeConfig sets the source's configuration from a typed configuration. Fields of the existing
+// configuration which are unknown to the typed configuration are preserved. If the source has no
+// type yet, it is set from the configuration.
+func (s *Source) EncodeConfig(config SourceConfig) error {
+	if s.Type == "" {
+		s.Type = config.SourceType()
+	} else if s.Type != config.SourceType() {
+		return ErrConfigTypeMismatch
+	}
+
+	if raw, ok := config.(*RawConfig); ok {
+		s.Config = raw.JSON
+		return nil
+	}
+
+	data, err := encodeConfig(s.Config, config)
+	if err != nil {
+		return err
+	}
+
+	s.Config = data
+	return nil
+}
+
+// HTTPSourceConfig is the configuration of HTTP sources.
+type HTTPSourceConfig struct {
+	EventUpload   bool  `json:"eventUpload,omitempty"`
+	EventUploadTS int64 `json:"eventUploadTS,omitempty"`
+}
+
+func (c *HTTPSourceConfig) SourceType() string {
+	return "HTTP"
+}
+
+// SDKSourceConfig is the configuration of sources fed by RudderStack SDKs, i.e. Javascript
+// and mobile (Android, iOS, ReactNative, Flutter) sources.
+type SDKSourceConfig struct {
+	// Type is the source type, e.g. "Android". It is not part of the configuration itself.
+	Type               string   `json:"-"`
+	EventUpload        bool     `json:"eventUpload,omitempty"`
+	EventUploadTS      int64    `json:"eventUploadTS,omitempty"`
+	CORSAllowedOrigins []string `json:"corsAllowedOrigins,omitempty"`
+}
+
+func (c *SDKSourceConfig) SourceType() string {
+	return c.Type
+}
+
+// CloudSourceSchedule is the synchronization schedule of a cloud source.
+type CloudSourceSchedule struct {
+	Type  string `json:"type,omitempty"`
+	Every string `json:"every,omitempty"`
+	Unit  string `json:"unit,omitempty"`
+}
+
+// CloudSourceConfig is the configuration of cloud sources, which periodically import data from
+// third party services (e.g. Stripe, Salesforce).
+type CloudSourceConfig struct {
+	// Type is the source type, e.g. "Stripe". It is not part of the configuration itself.
+	Type            string               `json:"-"`
+	RudderAccountID string               `json:"rudderAccountId,omitempty"`
+	Prefix          string               `json:"prefix,omitempty"`
+	Schedule        *CloudSourceSchedule `json:"schedule,omitempty"`
+}
+
+func (c *CloudSourceConfig) SourceType() string {
+	return c.Type
+}
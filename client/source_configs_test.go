@@ -0,0 +1,70 @@
+package client_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceDecodeConfig(t *testing.T) {
+	source := &client.Source{
+		Type:   "Android",
+		Config: json.RawMessage(`{"eventUpload": true, "someUnknownField": {"key": "val"}}`),
+	}
+
+	config, err := source.DecodeConfig()
+	require.NoError(t, err)
+	assert.Equal(t, &client.SDKSourceConfig{Type: "Android", EventUpload: true}, config)
+}
+
+func TestSourceEncodeConfigLossless(t *testing.T) {
+	original := json.RawMessage(`{"rudderAccountId": "some-account", "schedule": {"type": "basic", "every": "30", "unit": "minutes"}, "someUnknownField": 1}`)
+	source := &client.Source{Type: "Stripe", Config: original}
+
+	config, err := source.DecodeConfig()
+	require.NoError(t, err)
+	require.NoError(t, source.EncodeConfig(config))
+	assert.Equal(t, string(original), string(source.Config))
+
+	config.(*client.CloudSourceConfig).Schedule.Every = "60"
+	require.NoError(t, source.EncodeConfig(config))
+	assert.JSONEq(t, `{"rudderAccountId": "some-account", "schedule": {"type": "basic", "every": "60", "unit": "minutes"}, "someUnknownField": 1}`, string(source.Config))
+}
+
+func TestSourceEncodeConfigLosslessNested(t *testing.T) {
+	original := json.RawMessage(`{"schedule": {"type": "basic", "every": "30", "unit": "minutes", "timezone": "UTC"}}`)
+	source := &client.Source{Type: "Stripe", Config: original}
+
+	config, err := source.DecodeConfig()
+	require.NoError(t, err)
+	require.NoError(t, source.EncodeConfig(config))
+	assert.Equal(t, string(original), string(source.Config))
+
+	config.(*client.CloudSourceConfig).Schedule.Every = "60"
+	require.NoError(t, source.EncodeConfig(config))
+	assert.JSONEq(t, `{"schedule": {"type": "basic", "every": "60", "unit": "minutes", "timezone": "UTC"}}`, string(source.Config))
+}
+
+func TestSourceEncodeConfigNew(t *testing.T) {
+	source := &client.Source{Name: "some-name"}
+
+	require.NoError(t, source.EncodeConfig(&client.HTTPSourceConfig{EventUpload: true}))
+	assert.Equal(t, "HTTP", source.Type)
+	assert.JSONEq(t, `{"eventUpload": true}`, string(source.Config))
+
+	assert.Equal(t, client.ErrConfigTypeMismatch, source.EncodeConfig(&client.SDKSourceConfig{Type: "iOS"}))
+}
+
+func TestSourceDecodeConfigUnknownType(t *testing.T) {
+	source := &client.Source{Type: "SOME_TYPE", Config: json.RawMessage(`{"key": "val"}`)}
+
+	config, err := source.DecodeConfig()
+	require.NoError(t, err)
+	assert.Equal(t, &client.RawConfig{Type: "SOME_TYPE", JSON: json.RawMessage(`{"key": "val"}`)}, config)
+
+	require.NoError(t, source.EncodeConfig(config))
+	assert.Equal(t, `{"key": "val"}`, string(source.Config))
+}
@@ -17,6 +17,23 @@ type Source struct {
 	UpdatedAt *time.Time      `json:"updatedAt,omitempty"`
 }
 
+// SourcePatch is a partial update of a source. Nil fields are left untouched.
+type SourcePatch struct {
+	Name      *string `json:"name,omitempty"`
+	IsEnabled *bool   `json:"enabled,omitempty"`
+	// Config is a JSON merge patch of the config: its fields are set recursively, and fields set to null are removed.
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// SourceFilter selects sources for bulk operations. Empty fields match all sources.
+type SourceFilter struct {
+	Type string
+}
+
+func (f SourceFilter) matches(source Source) bool {
+	return f.Type == "" || f.Type == source.Type
+}
+
 type sources struct {
 	*service
 }
@@ -44,6 +61,42 @@ func (s *sources) List(ctx context.Context) (*SourcesPage, error) {
 	return page, nil
 }
 
+// SourcesIterator iterates over all sources, fetching pages on demand.
+type SourcesIterator struct {
+	pager
+	sources []Source
+	index   int
+}
+
+// All returns an iterator over all sources. Pages are fetched lazily, as the iterator advances.
+// Any error, including the cancellation of ctx, stops the iteration and is available through Err.
+func (s *sources) All(ctx context.Context) *SourcesIterator {
+	return &SourcesIterator{pager: s.pager(ctx)}
+}
+
+// Next advances the iterator to the next source. It returns false when there are no more sources or an error occurred.
+func (it *SourcesIterator) Next() bool {
+	if it.stopped() {
+		return false
+	}
+
+	for it.index >= len(it.sources) {
+		page := &SourcesPage{}
+		if !it.fetch(page, &page.APIPage) {
+			return false
+		}
+		it.sources, it.index = page.Sources, 0
+	}
+
+	it.index++
+	return true
+}
+
+// Source returns the current source.
+func (it *SourcesIterator) Source() Source {
+	return it.sources[it.index-1]
+}
+
 func (s *sources) Get(ctx context.Context, id string) (*Source, error) {
 	response := struct{ Source *Source }{}
 	if err := s.get(ctx, id, &response); err != nil {
@@ -54,6 +107,10 @@ func (s *sources) Get(ctx context.Context, id string) (*Source, error) {
 }
 
 func (s *sources) Create(ctx context.Context, source *Source) (*Source, error) {
+	if err := s.client.validateConfig(ctx, "source", source.Type, source.Config); err != nil {
+		return nil, err
+	}
+
 	// copy input and remove fields that should not be in request body without modifying input
 	src := *source
 	src.ID = ""
@@ -68,6 +125,10 @@ func (s *sources) Create(ctx context.Context, source *Source) (*Source, error) {
 }
 
 func (s *sources) Update(ctx context.Context, source *Source) (*Source, error) {
+	if err := s.client.validateConfig(ctx, "source", source.Type, source.Config); err != nil {
+		return nil, err
+	}
+
 	// copy input and remove ID from request body without modifying input
 	src := *source
 	src.ID = ""
@@ -80,6 +141,70 @@ func (s *sources) Update(ctx context.Context, source *Source) (*Source, error) {
 	return response.Source, nil
 }
 
+// Patch updates the fields set in patch, leaving the other fields of the source untouched. The source is fetched,
+// patched and updated, so changes made between the two requests are overwritten.
+func (s *sources) Patch(ctx context.Context, id string, patch SourcePatch) (*Source, error) {
+	source, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patched := &Source{}
+	if err := applyPatch(source, &patch, patched); err != nil {
+		return nil, err
+	}
+
+	return s.Update(ctx, patched)
+}
+
+// Enable enables a source, leaving its other fields untouched.
+func (s *sources) Enable(ctx context.Context, id string) (*Source, error) {
+	return s.Patch(ctx, id, SourcePatch{IsEnabled: Bool(true)})
+}
+
+// Disable disables a source, leaving its other fields untouched.
+func (s *sources) Disable(ctx context.Context, id string) (*Source, error) {
+	return s.Patch(ctx, id, SourcePatch{IsEnabled: Bool(false)})
+}
+
+// EnableMatching enables all the sources matching filter, with at most concurrency requests at a time. Sources which
+// are already enabled are left untouched, and the other ones are updated as listed, overwriting any change
+// made in the meantime. An error is returned only if the sources cannot be listed.
+func (s *sources) EnableMatching(ctx context.Context, filter SourceFilter, concurrency int) (*BulkResult, error) {
+	return s.setEnabledMatching(ctx, filter, true, concurrency)
+}
+
+// DisableMatching disables all the sources matching filter, with at most concurrency requests at a time. Sources which
+// are already disabled are left untouched, and the other ones are updated as listed, overwriting any change
+// made in the meantime. An error is returned only if the sources cannot be listed.
+func (s *sources) DisableMatching(ctx context.Context, filter SourceFilter, concurrency int) (*BulkResult, error) {
+	return s.setEnabledMatching(ctx, filter, false, concurrency)
+}
+
+func (s *sources) setEnabledMatching(ctx context.Context, filter SourceFilter, enabled bool, concurrency int) (*BulkResult, error) {
+	var targets []bulkTarget
+	matching := map[string]Source{}
+	it := s.All(ctx)
+	for it.Next() {
+		source := it.Source()
+		if filter.matches(source) {
+			targets = append(targets, bulkTarget{id: source.ID, skip: source.IsEnabled == enabled})
+			matching[source.ID] = source
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	// the listed sources are updated as they are, saving a request per source
+	return runBulk(ctx, targets, concurrency, func(ctx context.Context, id string) error {
+		source := matching[id]
+		source.IsEnabled = enabled
+		_, err := s.Update(ctx, &source)
+		return err
+	}), nil
+}
+
 func (s *sources) Delete(ctx context.Context, id string) error {
 	return s.service.delete(ctx, id)
 }
@@ -235,3 +235,170 @@ func TestClientSourcesUpdate(t *testing.T) {
 
 	httpClient.AssertNumberOfCalls()
 }
+
+func TestClientSourcesPatch(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/sources/some-id", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"source": {
+					"id": "some-id",
+					"name": "some-name",
+					"type": "some-type",
+					"writeKey": "some-write-key",
+					"enabled": true,
+					"config": { "key1": "val1", "key3": { "nested": true } }
+				}
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "PUT", "https://api.rudderstack.com/v2/sources/some-id", `{
+					"name": "other-name",
+					"type": "some-type",
+					"writeKey": "some-write-key",
+					"enabled": true,
+					"config": { "key2": "val2", "key3": { "nested": true } }
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"source": {
+					"id": "some-id",
+					"name": "other-name",
+					"type": "some-type",
+					"enabled": true,
+					"config": { "key2": "val2", "key3": { "nested": true } }
+				}
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	source, err := c.Sources.Patch(ctx, "some-id", client.SourcePatch{
+		Name:   client.String("other-name"),
+		Config: json.RawMessage(`{ "key1": null, "key2": "val2" }`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "other-name", source.Name)
+	assert.True(t, source.IsEnabled)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientSourcesAll(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/sources", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"sources": [{ "id": "id-1" }, { "id": "id-2" }],
+				"paging": { "total": 3, "next": "/sources?page=2" }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/sources?page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"sources": [{ "id": "id-3" }],
+				"paging": { "total": 3 }
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	var ids []string
+	it := c.Sources.All(ctx)
+	for it.Next() {
+		ids = append(ids, it.Source().ID)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"id-1", "id-2", "id-3"}, ids)
+	assert.False(t, it.Next())
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientSourcesAllError(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"sources": [{ "id": "id-1" }],
+				"paging": { "total": 2, "next": "/sources?page=2" }
+			}`,
+		},
+		{
+			ResponseStatus: 500,
+			ResponseBody:   `{ "error": "some error", "code": "some-code" }`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	var ids []string
+	it := c.Sources.All(ctx)
+	for it.Next() {
+		ids = append(ids, it.Source().ID)
+	}
+	assert.Equal(t, []string{"id-1"}, ids)
+
+	apiErr, ok := it.Err().(*client.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "some-code", apiErr.ErrorCode)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientSourcesAllCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := []testutils.Call{
+		{
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"sources": [{ "id": "id-1" }, { "id": "id-2" }],
+				"paging": { "total": 3, "next": "/sources?page=2" }
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	it := c.Sources.All(ctx)
+	require.True(t, it.Next())
+	assert.Equal(t, "id-1", it.Source().ID)
+
+	cancel()
+	assert.False(t, it.Next())
+	assert.Equal(t, context.Canceled, it.Err())
+
+	httpClient.AssertNumberOfCalls()
+}
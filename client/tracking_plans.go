@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Types of the events governed by tracking plans
+const (
+	EventTypeTrack    = "track"
+	EventTypeIdentify = "identify"
+	EventTypeGroup    = "group"
+	EventTypePage     = "page"
+	EventTypeScreen   = "screen"
+)
+
+type TrackingPlan struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// SourceIDs are the IDs of the sources the tracking plan is linked to. They are managed with LinkSource and UnlinkSource.
+	SourceIDs []string   `json:"sourceIds,omitempty"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// TrackingPlanEvent is an event of a tracking plan, with the rules its payload must follow.
+type TrackingPlanEvent struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Type        string `json:"eventType"`
+	Description string `json:"description,omitempty"`
+	// Rules is the JSON Schema the properties (or traits) of the event must match, e.g.
+	// {"type": "object", "properties": {"price": {"type": "number"}}, "required": ["price"]}.
+	Rules     json.RawMessage `json:"rules,omitempty"`
+	CreatedAt *time.Time      `json:"createdAt,omitempty"`
+	UpdatedAt *time.Time      `json:"updatedAt,omitempty"`
+}
+
+type trackingPlans struct {
+	*service
+}
+
+type TrackingPlansPage struct {
+	APIPage
+	TrackingPlans []TrackingPlan `json:"trackingPlans"`
+}
+
+type TrackingPlanEventsPage struct {
+	APIPage
+	Events []TrackingPlanEvent `json:"events"`
+}
+
+func (s *trackingPlans) Next(ctx context.Context, paging Paging) (*TrackingPlansPage, error) {
+	page := &TrackingPlansPage{}
+	ok, err := s.service.next(ctx, paging, page)
+	if !ok {
+		page = nil
+	}
+	return page, err
+}
+
+func (s *trackingPlans) List(ctx context.Context) (*TrackingPlansPage, error) {
+	page := &TrackingPlansPage{}
+	if err := s.list(ctx, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// TrackingPlansIterator iterates over all tracking plans, fetching pages on demand.
+type TrackingPlansIterator struct {
+	pager
+	trackingPlans []TrackingPlan
+	index         int
+}
+
+// All returns an iterator over all tracking plans. Pages are fetched lazily, as the iterator advances.
+// Any error, including the cancellation of ctx, stops the iteration and is available through Err.
+func (s *trackingPlans) All(ctx context.Context) *TrackingPlansIterator {
+	return &TrackingPlansIterator{pager: s.pager(ctx)}
+}
+
+// Next advances the iterator to the next tracking plan. It returns false when there are no more tracking plans or an error occurred.
+func (it *TrackingPlansIterator) Next() bool {
+	if it.stopped() {
+		return false
+	}
+
+	for it.index >= len(it.trackingPlans) {
+		page := &TrackingPlansPage{}
+		if !it.fetch(page, &page.APIPage) {
+			return false
+		}
+		it.trackingPlans, it.index = page.TrackingPlans, 0
+	}
+
+	it.index++
+	return true
+}
+
+// TrackingPlan returns the current tracking plan.
+func (it *TrackingPlansIterator) TrackingPlan() TrackingPlan {
+	return it.trackingPlans[it.index-1]
+}
+
+func (s *trackingPlans) Get(ctx context.Context, id string) (*TrackingPlan, error) {
+	response := struct{ TrackingPlan *TrackingPlan }{}
+	if err := s.get(ctx, id, &response); err != nil {
+		return nil, err
+	}
+
+	return response.TrackingPlan, nil
+}
+
+func (s *trackingPlans) Create(ctx context.Context, trackingPlan *TrackingPlan) (*TrackingPlan, error) {
+	// copy input and remove fields that should not be in request body without modifying input
+	tp := *trackingPlan
+	tp.ID = ""
+	tp.SourceIDs = nil
+
+	response := struct{ TrackingPlan *TrackingPlan }{}
+	if err := s.create(ctx, &tp, &response); err != nil {
+		return nil, err
+	}
+
+	return response.TrackingPlan, nil
+}
+
+func (s *trackingPlans) Update(ctx context.Context, trackingPlan *TrackingPlan) (*TrackingPlan, error) {
+	// copy input and remove fields that should not be in request body without modifying input
+	tp := *trackingPlan
+	tp.ID = ""
+	tp.SourceIDs = nil
+
+	response := struct{ TrackingPlan *TrackingPlan }{}
+	if err := s.update(ctx, trackingPlan.ID, &tp, &response); err != nil {
+		return nil, err
+	}
+
+	return response.TrackingPlan, nil
+}
+
+func (s *trackingPlans) Delete(ctx context.Context, id string) error {
+	return s.service.delete(ctx, id)
+}
+
+// ListEvents returns the first page of the events of a tracking plan.
+func (s *trackingPlans) ListEvents(ctx context.Context, id string) (*TrackingPlanEventsPage, error) {
+	page := &TrackingPlanEventsPage{}
+	if err := s.action(ctx, "ListEvents", "GET", []string{id, "events"}, nil, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// NextEvents returns the next page of events of a tracking plan, or nil if there are no more pages.
+func (s *trackingPlans) NextEvents(ctx context.Context, paging Paging) (*TrackingPlanEventsPage, error) {
+	page := &TrackingPlanEventsPage{}
+	ok, err := s.nextPage(ctx, "ListEvents", paging, page)
+	if !ok {
+		page = nil
+	}
+	return page, err
+}
+
+func (s *trackingPlans) GetEvent(ctx context.Context, id, eventID string) (*TrackingPlanEvent, error) {
+	response := struct{ Event *TrackingPlanEvent }{}
+	if err := s.action(ctx, "GetEvent", "GET", []string{id, "events", eventID}, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Event, nil
+}
+
+// AddEvent adds an event, with its rules, to a tracking plan.
+func (s *trackingPlans) AddEvent(ctx context.Context, id string, event *TrackingPlanEvent) (*TrackingPlanEvent, error) {
+	// copy input and remove ID from request body without modifying input
+	e := *event
+	e.ID = ""
+
+	response := struct{ Event *TrackingPlanEvent }{}
+	if err := s.action(ctx, "AddEvent", "POST", []string{id, "events"}, &e, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Event, nil
+}
+
+// UpdateEvent updates an event of a tracking plan, replacing its rules.
+func (s *trackingPlans) UpdateEvent(ctx context.Context, id string, event *TrackingPlanEvent) (*TrackingPlanEvent, error) {
+	// copy input and remove ID from request body without modifying input
+	e := *event
+	e.ID = ""
+
+	response := struct{ Event *TrackingPlanEvent }{}
+	if err := s.action(ctx, "UpdateEvent", "PUT", []string{id, "events", event.ID}, &e, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Event, nil
+}
+
+// RemoveEvent removes an event from a tracking plan.
+func (s *trackingPlans) RemoveEvent(ctx context.Context, id, eventID string) error {
+	return s.action(ctx, "RemoveEvent", "DELETE", []string{id, "events", eventID}, nil, nil)
+}
+
+// LinkSource links a tracking plan to a source, so that the events of the source are validated against it.
+// A source is linked to at most one tracking plan.
+func (s *trackingPlans) LinkSource(ctx context.Context, id, sourceID string) error {
+	return s.action(ctx, "LinkSource", "PUT", []string{id, "sources", sourceID}, nil, nil)
+}
+
+// UnlinkSource unlinks a tracking plan from a source.
+func (s *trackingPlans) UnlinkSource(ctx context.Context, id, sourceID string) error {
+	return s.action(ctx, "UnlinkSource", "DELETE", []string{id, "sources", sourceID}, nil, nil)
+}
@@ -0,0 +1,250 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientTrackingPlansList(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/trackingPlans", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"trackingPlans": [{ "id": "id-1", "name": "name-1", "sourceIds": ["source-1"] }],
+				"paging": { "total": 2, "next": "/trackingPlans?page=2" }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/trackingPlans?page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"trackingPlans": [{ "id": "id-2", "name": "name-2" }],
+				"paging": { "total": 2 }
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	var plans []client.TrackingPlan
+	it := c.TrackingPlans.All(ctx)
+	for it.Next() {
+		plans = append(plans, it.TrackingPlan())
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []client.TrackingPlan{
+		{ID: "id-1", Name: "name-1", SourceIDs: []string{"source-1"}},
+		{ID: "id-2", Name: "name-2"},
+	}, plans)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientTrackingPlansCRUD(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/trackingPlans", `{
+					"name": "some-name",
+					"description": "some-description"
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "trackingPlan": { "id": "some-id", "name": "some-name", "description": "some-description" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "PUT", "https://api.rudderstack.com/v2/trackingPlans/some-id", `{
+					"name": "other-name",
+					"description": "some-description"
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "trackingPlan": { "id": "some-id", "name": "other-name", "description": "some-description", "sourceIds": ["source-1"] } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/trackingPlans/some-id", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "trackingPlan": { "id": "some-id", "name": "other-name" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "DELETE", "https://api.rudderstack.com/v2/trackingPlans/some-id", "")
+			},
+			ResponseStatus: 204,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	plan, err := c.TrackingPlans.Create(ctx, &client.TrackingPlan{Name: "some-name", Description: "some-description"})
+	require.NoError(t, err)
+	assert.Equal(t, "some-id", plan.ID)
+
+	// linked sources are not part of the request body
+	plan.Name = "other-name"
+	plan.SourceIDs = []string{"source-1"}
+	plan, err = c.TrackingPlans.Update(ctx, plan)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"source-1"}, plan.SourceIDs)
+
+	plan, err = c.TrackingPlans.Get(ctx, "some-id")
+	require.NoError(t, err)
+	assert.Equal(t, "other-name", plan.Name)
+
+	require.NoError(t, c.TrackingPlans.Delete(ctx, "some-id"))
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientTrackingPlansEvents(t *testing.T) {
+	ctx := context.Background()
+
+	rules := json.RawMessage(`{"type":"object","properties":{"price":{"type":"number"}},"required":["price"]}`)
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/trackingPlans/some-id/events", `{
+					"name": "Product Viewed",
+					"eventType": "track",
+					"rules": {"type":"object","properties":{"price":{"type":"number"}},"required":["price"]}
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "event": { "id": "event-id", "name": "Product Viewed", "eventType": "track" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "PUT", "https://api.rudderstack.com/v2/trackingPlans/some-id/events/event-id", `{
+					"name": "Product Viewed",
+					"eventType": "track",
+					"rules": {"type":"object"}
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "event": { "id": "event-id", "name": "Product Viewed", "eventType": "track", "rules": {"type":"object"} } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/trackingPlans/some-id/events/event-id", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "event": { "id": "event-id", "name": "Product Viewed", "eventType": "track" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/trackingPlans/some-id/events", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"events": [{ "id": "event-id", "name": "Product Viewed", "eventType": "track" }],
+				"paging": { "total": 2, "next": "/trackingPlans/some-id/events?page=2" }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/trackingPlans/some-id/events?page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"events": [{ "id": "other-id", "name": "Signed Up", "eventType": "identify" }],
+				"paging": { "total": 2 }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "DELETE", "https://api.rudderstack.com/v2/trackingPlans/some-id/events/event-id", "")
+			},
+			ResponseStatus: 204,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	event, err := c.TrackingPlans.AddEvent(ctx, "some-id", &client.TrackingPlanEvent{
+		Name:  "Product Viewed",
+		Type:  client.EventTypeTrack,
+		Rules: rules,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "event-id", event.ID)
+
+	event.Rules = json.RawMessage(`{"type":"object"}`)
+	event, err = c.TrackingPlans.UpdateEvent(ctx, "some-id", event)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"object"}`, string(event.Rules))
+
+	event, err = c.TrackingPlans.GetEvent(ctx, "some-id", "event-id")
+	require.NoError(t, err)
+	assert.Equal(t, "Product Viewed", event.Name)
+
+	page, err := c.TrackingPlans.ListEvents(ctx, "some-id")
+	require.NoError(t, err)
+	assert.Len(t, page.Events, 1)
+
+	page, err = c.TrackingPlans.NextEvents(ctx, page.Paging)
+	require.NoError(t, err)
+	require.Len(t, page.Events, 1)
+	assert.Equal(t, client.EventTypeIdentify, page.Events[0].Type)
+
+	require.NoError(t, c.TrackingPlans.RemoveEvent(ctx, "some-id", "event-id"))
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientTrackingPlansSources(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "PUT", "https://api.rudderstack.com/v2/trackingPlans/some-id/sources/source-id", "")
+			},
+			ResponseStatus: 204,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "DELETE", "https://api.rudderstack.com/v2/trackingPlans/some-id/sources/source-id", "")
+			},
+			ResponseStatus: 204,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	require.NoError(t, c.TrackingPlans.LinkSource(ctx, "some-id", "source-id"))
+	require.NoError(t, c.TrackingPlans.UnlinkSource(ctx, "some-id", "source-id"))
+
+	httpClient.AssertNumberOfCalls()
+}
@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// TransformationLibrary holds code shared by transformations, which import it by its ImportName.
+type TransformationLibrary struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Code        string `json:"code"`
+	Language    string `json:"language"`
+	// ImportName is the name transformations use to import the library. It is derived from Name by the API.
+	ImportName string `json:"importName,omitempty"`
+	// VersionID is the ID of the current version of the library.
+	VersionID string     `json:"versionId,omitempty"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+type transformationLibraries struct {
+	*service
+	transformations *transformations
+}
+
+type TransformationLibrariesPage struct {
+	APIPage
+	Libraries []TransformationLibrary `json:"libraries"`
+}
+
+func (s *transformationLibraries) Next(ctx context.Context, paging Paging) (*TransformationLibrariesPage, error) {
+	page := &TransformationLibrariesPage{}
+	ok, err := s.service.next(ctx, paging, page)
+	if !ok {
+		page = nil
+	}
+	return page, err
+}
+
+func (s *transformationLibraries) List(ctx context.Context) (*TransformationLibrariesPage, error) {
+	page := &TransformationLibrariesPage{}
+	if err := s.list(ctx, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// TransformationLibrariesIterator iterates over all transformation libraries, fetching pages on demand.
+type TransformationLibrariesIterator struct {
+	pager
+	libraries []TransformationLibrary
+	index     int
+}
+
+// All returns an iterator over all transformation libraries. Pages are fetched lazily, as the iterator advances.
+// Any error, including the cancellation of ctx, stops the iteration and is available through Err.
+func (s *transformationLibraries) All(ctx context.Context) *TransformationLibrariesIterator {
+	return &TransformationLibrariesIterator{pager: s.pager(ctx)}
+}
+
+// Next advances the iterator to the next library. It returns false when there are no more libraries or an error occurred.
+func (it *TransformationLibrariesIterator) Next() bool {
+	if it.stopped() {
+		return false
+	}
+
+	for it.index >= len(it.libraries) {
+		page := &TransformationLibrariesPage{}
+		if !it.fetch(page, &page.APIPage) {
+			return false
+		}
+		it.libraries, it.index = page.Libraries, 0
+	}
+
+	it.index++
+	return true
+}
+
+// Library returns the current library.
+func (it *TransformationLibrariesIterator) Library() TransformationLibrary {
+	return it.libraries[it.index-1]
+}
+
+func (s *transformationLibraries) Get(ctx context.Context, id string) (*TransformationLibrary, error) {
+	response := struct{ Library *TransformationLibrary }{}
+	if err := s.get(ctx, id, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Library, nil
+}
+
+func (s *transformationLibraries) Create(ctx context.Context, library *TransformationLibrary) (*TransformationLibrary, error) {
+	// copy input and remove fields that should not be in request body without modifying input
+	l := *library
+	l.ID = ""
+	l.ImportName = ""
+	l.VersionID = ""
+
+	response := struct{ Library *TransformationLibrary }{}
+	if err := s.create(ctx, &l, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Library, nil
+}
+
+// Update updates a library, creating a new unpublished version of it.
+func (s *transformationLibraries) Update(ctx context.Context, library *TransformationLibrary) (*TransformationLibrary, error) {
+	// copy input and remove fields that should not be in request body without modifying input
+	l := *library
+	l.ID = ""
+	l.ImportName = ""
+	l.VersionID = ""
+
+	response := struct{ Library *TransformationLibrary }{}
+	if err := s.update(ctx, library.ID, &l, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Library, nil
+}
+
+func (s *transformationLibraries) Delete(ctx context.Context, id string) error {
+	return s.service.delete(ctx, id)
+}
+
+// Publish publishes the latest version of a library. Transformations importing it only pick it up once they are
+// published again, see PublishWithDependents.
+func (s *transformationLibraries) Publish(ctx context.Context, id string) (*TransformationLibrary, error) {
+	response := struct{ Library *TransformationLibrary }{}
+	if err := s.action(ctx, "Publish", "POST", []string{id, "publish"}, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Library, nil
+}
+
+// ListVersions returns the first page of the versions of a library, most recent first.
+func (s *transformationLibraries) ListVersions(ctx context.Context, id string) (*TransformationVersionsPage, error) {
+	page := &TransformationVersionsPage{}
+	if err := s.action(ctx, "ListVersions", "GET", []string{id, "versions"}, nil, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// NextVersions returns the next page of versions of a library, or nil if there are no more pages.
+func (s *transformationLibraries) NextVersions(ctx context.Context, paging Paging) (*TransformationVersionsPage, error) {
+	page := &TransformationVersionsPage{}
+	ok, err := s.nextPage(ctx, "ListVersions", paging, page)
+	if !ok {
+		page = nil
+	}
+	return page, err
+}
+
+// PublishResult lists the library and the transformations published by PublishWithDependents.
+type PublishResult struct {
+	Library         *TransformationLibrary
+	Transformations []Transformation
+}
+
+// PublishWithDependents publishes a library, and then every transformation importing it, so that they all run
+// the latest version of the library. Dependent transformations are found by scanning their code for imports of
+// the library's ImportName. If publishing a transformation fails, the result holds what was published so far.
+func (s *transformationLibraries) PublishWithDependents(ctx context.Context, id string) (*PublishResult, error) {
+	library, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []Transformation
+	it := s.transformations.All(ctx)
+	for it.Next() {
+		if t := it.Transformation(); importsLibrary(t.Code, library.ImportName) {
+			dependents = append(dependents, t)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &PublishResult{}
+	if result.Library, err = s.Publish(ctx, id); err != nil {
+		return nil, err
+	}
+
+	for _, dependent := range dependents {
+		t, err := s.transformations.Publish(ctx, dependent.ID)
+		if err != nil {
+			return result, fmt.Errorf("publishing transformation '%s': %w", dependent.ID, err)
+		}
+		result.Transformations = append(result.Transformations, *t)
+	}
+
+	return result, nil
+}
+
+// importsLibrary reports whether code imports the library, either with JavaScript import or require statements,
+// or with Python import statements.
+func importsLibrary(code, importName string) bool {
+	if importName == "" {
+		return false
+	}
+
+	name := regexp.QuoteMeta(importName)
+	pattern := regexp.MustCompile(`(?m)(?:\bfrom\s*|\brequire\(\s*)["']` + name + `["']|^\s*(?:from|import)\s+` + name + `\b`)
+	return pattern.MatchString(code)
+}
@@ -0,0 +1,257 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientTransformationLibrariesList(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/transformationLibraries", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"libraries": [{ "id": "id-1", "name": "Some Library", "importName": "someLibrary", "language": "javascript" }],
+				"paging": { "total": 2, "next": "/transformationLibraries?page=2" }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/transformationLibraries?page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"libraries": [{ "id": "id-2" }],
+				"paging": { "total": 2 }
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	page, err := c.TransformationLibraries.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []client.TransformationLibrary{{ID: "id-1", Name: "Some Library", ImportName: "someLibrary", Language: client.LanguageJavaScript}}, page.Libraries)
+
+	page, err = c.TransformationLibraries.Next(ctx, page.Paging)
+	require.NoError(t, err)
+	assert.Equal(t, []client.TransformationLibrary{{ID: "id-2"}}, page.Libraries)
+
+	page, err = c.TransformationLibraries.Next(ctx, page.Paging)
+	require.NoError(t, err)
+	assert.Nil(t, page)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientTransformationLibrariesCRUD(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/transformationLibraries", `{
+					"name": "Some Library",
+					"code": "export function f() {}",
+					"language": "javascript"
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "library": { "id": "some-id", "name": "Some Library", "importName": "someLibrary", "versionId": "version-1" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "PUT", "https://api.rudderstack.com/v2/transformationLibraries/some-id", `{
+					"name": "Some Library",
+					"code": "export function g() {}",
+					"language": "javascript"
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "library": { "id": "some-id", "versionId": "version-2" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/transformationLibraries/some-id", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "library": { "id": "some-id", "versionId": "version-2" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "DELETE", "https://api.rudderstack.com/v2/transformationLibraries/some-id", "")
+			},
+			ResponseStatus: 204,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	library, err := c.TransformationLibraries.Create(ctx, &client.TransformationLibrary{
+		Name:     "Some Library",
+		Code:     "export function f() {}",
+		Language: client.LanguageJavaScript,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "someLibrary", library.ImportName)
+
+	library.Code = "export function g() {}"
+	library.Language = client.LanguageJavaScript
+	library, err = c.TransformationLibraries.Update(ctx, library)
+	require.NoError(t, err)
+	assert.Equal(t, "version-2", library.VersionID)
+
+	library, err = c.TransformationLibraries.Get(ctx, "some-id")
+	require.NoError(t, err)
+	assert.Equal(t, "version-2", library.VersionID)
+
+	require.NoError(t, c.TransformationLibraries.Delete(ctx, "some-id"))
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientTransformationLibrariesVersions(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/transformationLibraries/some-id/versions", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"versions": [{ "id": "version-2" }],
+				"paging": { "total": 2, "next": "/transformationLibraries/some-id/versions?page=2" }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/transformationLibraries/some-id/versions?page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"versions": [{ "id": "version-1", "published": true }],
+				"paging": { "total": 2 }
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	page, err := c.TransformationLibraries.ListVersions(ctx, "some-id")
+	require.NoError(t, err)
+	assert.Equal(t, []client.TransformationVersion{{ID: "version-2"}}, page.Versions)
+
+	page, err = c.TransformationLibraries.NextVersions(ctx, page.Paging)
+	require.NoError(t, err)
+	assert.Equal(t, []client.TransformationVersion{{ID: "version-1", Published: true}}, page.Versions)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientTransformationLibrariesPublishWithDependents(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/transformationLibraries/some-id", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "library": { "id": "some-id", "importName": "someLibrary" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/transformations", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"transformations": [
+					{ "id": "js-import", "code": "import { f } from \"someLibrary\";\nexport function transformEvent(event) { return f(event); }" },
+					{ "id": "js-require", "code": "const lib = require('someLibrary');" },
+					{ "id": "other-library", "code": "import { f } from \"someLibraryV2\";" }
+				],
+				"paging": { "total": 5, "next": "/transformations?page=2" }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/transformations?page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"transformations": [
+					{ "id": "python-import", "code": "from someLibrary import f\ndef transformEvent(event, metadata):\n    return f(event)", "language": "pythonfaas" },
+					{ "id": "no-import", "code": "// someLibrary is not used anymore" }
+				],
+				"paging": { "total": 5 }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/transformationLibraries/some-id/publish", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "library": { "id": "some-id", "versionId": "version-2" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/transformations/js-import/publish", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "transformation": { "id": "js-import" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/transformations/js-require/publish", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "transformation": { "id": "js-require" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/transformations/python-import/publish", "")
+			},
+			ResponseStatus: 500,
+			ResponseBody:   `{ "error": "some error", "code": "some-code" }`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	result, err := c.TransformationLibraries.PublishWithDependents(ctx, "some-id")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "python-import")
+
+	var apiErr *client.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "some-code", apiErr.ErrorCode)
+
+	require.NotNil(t, result)
+	assert.Equal(t, "version-2", result.Library.VersionID)
+	assert.Equal(t, []client.Transformation{{ID: "js-import"}, {ID: "js-require"}}, result.Transformations)
+
+	httpClient.AssertNumberOfCalls()
+}
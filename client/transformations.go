@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// Languages of transformations and transformation libraries
+const (
+	LanguageJavaScript = "javascript"
+	LanguagePython     = "pythonfaas"
+)
+
+type Transformation struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Code        string `json:"code"`
+	Language    string `json:"language"`
+	// VersionID is the ID of the current version of the transformation.
+	VersionID string `json:"versionId,omitempty"`
+	// DestinationIDs are the IDs of the destinations the transformation is connected to. They are managed with
+	// ConnectDestination and DisconnectDestination.
+	DestinationIDs []string   `json:"destinationIds,omitempty"`
+	CreatedAt      *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt      *time.Time `json:"updatedAt,omitempty"`
+}
+
+// TransformationVersion is a revision of the code of a transformation or of a transformation library.
+type TransformationVersion struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Code        string     `json:"code"`
+	Language    string     `json:"language"`
+	Published   bool       `json:"published"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+}
+
+type transformations struct {
+	*service
+}
+
+type TransformationsPage struct {
+	APIPage
+	Transformations []Transformation `json:"transformations"`
+}
+
+type TransformationVersionsPage struct {
+	APIPage
+	Versions []TransformationVersion `json:"versions"`
+}
+
+func (s *transformations) Next(ctx context.Context, paging Paging) (*TransformationsPage, error) {
+	page := &TransformationsPage{}
+	ok, err := s.service.next(ctx, paging, page)
+	if !ok {
+		page = nil
+	}
+	return page, err
+}
+
+func (s *transformations) List(ctx context.Context) (*TransformationsPage, error) {
+	page := &TransformationsPage{}
+	if err := s.list(ctx, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// TransformationsIterator iterates over all transformations, fetching pages on demand.
+type TransformationsIterator struct {
+	pager
+	transformations []Transformation
+	index           int
+}
+
+// All returns an iterator over all transformations. Pages are fetched lazily, as the iterator advances.
+// Any error, including the cancellation of ctx, stops the iteration and is available through Err.
+func (s *transformations) All(ctx context.Context) *TransformationsIterator {
+	return &TransformationsIterator{pager: s.pager(ctx)}
+}
+
+// Next advances the iterator to the next transformation. It returns false when there are no more transformations or an error occurred.
+func (it *TransformationsIterator) Next() bool {
+	if it.stopped() {
+		return false
+	}
+
+	for it.index >= len(it.transformations) {
+		page := &TransformationsPage{}
+		if !it.fetch(page, &page.APIPage) {
+			return false
+		}
+		it.transformations, it.index = page.Transformations, 0
+	}
+
+	it.index++
+	return true
+}
+
+// Transformation returns the current transformation.
+func (it *TransformationsIterator) Transformation() Transformation {
+	return it.transformations[it.index-1]
+}
+
+func (s *transformations) Get(ctx context.Context, id string) (*Transformation, error) {
+	response := struct{ Transformation *Transformation }{}
+	if err := s.get(ctx, id, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Transformation, nil
+}
+
+func (s *transformations) Create(ctx context.Context, transformation *Transformation) (*Transformation, error) {
+	// copy input and remove fields that should not be in request body without modifying input
+	t := *transformation
+	t.ID = ""
+	t.VersionID = ""
+	t.DestinationIDs = nil
+
+	response := struct{ Transformation *Transformation }{}
+	if err := s.create(ctx, &t, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Transformation, nil
+}
+
+// Update updates a transformation, creating a new unpublished version of it.
+func (s *transformations) Update(ctx context.Context, transformation *Transformation) (*Transformation, error) {
+	// copy input and remove fields that should not be in request body without modifying input
+	t := *transformation
+	t.ID = ""
+	t.VersionID = ""
+	t.DestinationIDs = nil
+
+	response := struct{ Transformation *Transformation }{}
+	if err := s.update(ctx, transformation.ID, &t, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Transformation, nil
+}
+
+func (s *transformations) Delete(ctx context.Context, id string) error {
+	return s.service.delete(ctx, id)
+}
+
+// Publish publishes the latest version of a transformation, making it live for its connected destinations.
+func (s *transformations) Publish(ctx context.Context, id string) (*Transformation, error) {
+	response := struct{ Transformation *Transformation }{}
+	if err := s.action(ctx, "Publish", "POST", []string{id, "publish"}, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Transformation, nil
+}
+
+// ListVersions returns the first page of the versions of a transformation, most recent first.
+func (s *transformations) ListVersions(ctx context.Context, id string) (*TransformationVersionsPage, error) {
+	page := &TransformationVersionsPage{}
+	if err := s.action(ctx, "ListVersions", "GET", []string{id, "versions"}, nil, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// NextVersions returns the next page of versions of a transformation, or nil if there are no more pages.
+func (s *transformations) NextVersions(ctx context.Context, paging Paging) (*TransformationVersionsPage, error) {
+	page := &TransformationVersionsPage{}
+	ok, err := s.nextPage(ctx, "ListVersions", paging, page)
+	if !ok {
+		page = nil
+	}
+	return page, err
+}
+
+// ConnectDestination connects a transformation to a destination, so that events are transformed before being sent to it.
+func (s *transformations) ConnectDestination(ctx context.Context, id, destinationID string) error {
+	return s.action(ctx, "ConnectDestination", "PUT", []string{id, "destinations", destinationID}, nil, nil)
+}
+
+// DisconnectDestination disconnects a transformation from a destination.
+func (s *transformations) DisconnectDestination(ctx context.Context, id, destinationID string) error {
+	return s.action(ctx, "DisconnectDestination", "DELETE", []string{id, "destinations", destinationID}, nil, nil)
+}
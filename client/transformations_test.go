@@ -0,0 +1,288 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientTransformationsList(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/transformations", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"transformations": [{
+					"id": "id-1",
+					"name": "name-1",
+					"code": "export function transformEvent(event) { return event; }",
+					"language": "javascript",
+					"versionId": "version-1",
+					"destinationIds": ["destination-1"]
+				}],
+				"paging": {
+					"total": 2,
+					"next": "/transformations?page=2"
+				}
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/transformations?page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"transformations": [{ "id": "id-2", "name": "name-2", "language": "pythonfaas" }],
+				"paging": { "total": 2 }
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	page, err := c.Transformations.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, page.Transformations, 1)
+	assert.Equal(t, client.Transformation{
+		ID:             "id-1",
+		Name:           "name-1",
+		Code:           "export function transformEvent(event) { return event; }",
+		Language:       client.LanguageJavaScript,
+		VersionID:      "version-1",
+		DestinationIDs: []string{"destination-1"},
+	}, page.Transformations[0])
+	assert.Equal(t, "/transformations?page=2", page.Paging.Next)
+
+	page, err = c.Transformations.Next(ctx, page.Paging)
+	require.NoError(t, err)
+	require.Len(t, page.Transformations, 1)
+	assert.Equal(t, client.LanguagePython, page.Transformations[0].Language)
+
+	page, err = c.Transformations.Next(ctx, page.Paging)
+	require.NoError(t, err)
+	assert.Nil(t, page)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientTransformationsGet(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/transformations/some-id", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"transformation": {
+					"id": "some-id",
+					"name": "some-name",
+					"code": "some-code",
+					"language": "javascript",
+					"createdAt": "2020-01-01T01:01:01Z",
+					"updatedAt": "2020-01-02T01:01:01Z"
+				}
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	transformation, err := c.Transformations.Get(ctx, "some-id")
+	require.NoError(t, err)
+	assert.Equal(t, "some-id", transformation.ID)
+	assert.Equal(t, "some-name", transformation.Name)
+	assert.Equal(t, "some-code", transformation.Code)
+	assert.Equal(t, time.Date(2020, 1, 1, 1, 1, 1, 0, time.UTC), *transformation.CreatedAt)
+	assert.Equal(t, time.Date(2020, 1, 2, 1, 1, 1, 0, time.UTC), *transformation.UpdatedAt)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientTransformationsCreateAndUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/transformations", `{
+					"name": "some-name",
+					"code": "some-code",
+					"language": "javascript"
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "transformation": { "id": "some-id", "name": "some-name", "versionId": "version-1" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "PUT", "https://api.rudderstack.com/v2/transformations/some-id", `{
+					"name": "some-name",
+					"code": "other-code",
+					"language": "javascript"
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "transformation": { "id": "some-id", "name": "some-name", "versionId": "version-2" } }`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	input := &client.Transformation{Name: "some-name", Code: "some-code", Language: client.LanguageJavaScript}
+	transformation, err := c.Transformations.Create(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, "some-id", transformation.ID)
+	assert.Equal(t, "version-1", transformation.VersionID)
+
+	transformation.Code = "other-code"
+	transformation.Language = client.LanguageJavaScript
+	transformation.DestinationIDs = []string{"destination-1"}
+	transformation, err = c.Transformations.Update(ctx, transformation)
+	require.NoError(t, err)
+	assert.Equal(t, "version-2", transformation.VersionID)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientTransformationsDelete(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "DELETE", "https://api.rudderstack.com/v2/transformations/some-id", "")
+			},
+			ResponseStatus: 204,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	require.NoError(t, c.Transformations.Delete(ctx, "some-id"))
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientTransformationsVersions(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/transformations/some-id/versions", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"versions": [{ "id": "version-2", "code": "code-2", "published": false }],
+				"paging": { "total": 2, "next": "/transformations/some-id/versions?page=2" }
+			}`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/transformations/some-id/versions?page=2", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody: `{
+				"versions": [{ "id": "version-1", "code": "code-1", "published": true }],
+				"paging": { "total": 2 }
+			}`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	page, err := c.Transformations.ListVersions(ctx, "some-id")
+	require.NoError(t, err)
+	assert.Equal(t, []client.TransformationVersion{{ID: "version-2", Code: "code-2"}}, page.Versions)
+
+	page, err = c.Transformations.NextVersions(ctx, page.Paging)
+	require.NoError(t, err)
+	assert.Equal(t, []client.TransformationVersion{{ID: "version-1", Code: "code-1", Published: true}}, page.Versions)
+
+	page, err = c.Transformations.NextVersions(ctx, page.Paging)
+	require.NoError(t, err)
+	assert.Nil(t, page)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientTransformationsPublish(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/transformations/some-id/publish", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "transformation": { "id": "some-id", "versionId": "version-2" } }`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	transformation, err := c.Transformations.Publish(ctx, "some-id")
+	require.NoError(t, err)
+	assert.Equal(t, "version-2", transformation.VersionID)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientTransformationsDestinations(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "PUT", "https://api.rudderstack.com/v2/transformations/some-id/destinations/destination-id", "")
+			},
+			ResponseStatus: 204,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "DELETE", "https://api.rudderstack.com/v2/transformations/some-id/destinations/destination-id", "")
+			},
+			ResponseStatus: 204,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	require.NoError(t, c.Transformations.ConnectDestination(ctx, "some-id", "destination-id"))
+	require.NoError(t, c.Transformations.DisconnectDestination(ctx, "some-id", "destination-id"))
+
+	httpClient.AssertNumberOfCalls()
+}
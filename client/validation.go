@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/rudderlabs/rudder-api-go/internal/jsonschema"
+)
+
+var ErrInvalidSchemaProvider = fmt.Errorf("schema provider cannot be nil")
+
+// SchemaProvider provides the JSON Schemas which source and destination configs are validated against by
+// WithConfigValidation. A nil schema, with a nil error, disables the validation of a type.
+type SchemaProvider interface {
+	SourceSchema(ctx context.Context, typ string) (json.RawMessage, error)
+	DestinationSchema(ctx context.Context, typ string) (json.RawMessage, error)
+}
+
+//go:embed schemas/*.json
+var bundledSchemaFiles embed.FS
+
+type bundledSchemas struct {
+	sources      map[string]json.RawMessage
+	destinations map[string]json.RawMessage
+}
+
+var (
+	bundledSchemasOnce sync.Once
+	bundledSchemasErr  error
+	bundled            = &bundledSchemas{}
+)
+
+// BundledSchemas returns a SchemaProvider of the config schemas shipped with this package, which cover the types with
+// a typed configuration. Other types are not validated.
+func BundledSchemas() SchemaProvider {
+	bundledSchemasOnce.Do(func() {
+		for file, schemas := range map[string]*map[string]json.RawMessage{
+			"schemas/sources.json":      &bundled.sources,
+			"schemas/destinations.json": &bundled.destinations,
+		} {
+			data, err := bundledSchemaFiles.ReadFile(file)
+			if err == nil {
+				err = json.Unmarshal(data, schemas)
+			}
+			if err != nil {
+				bundledSchemasErr = fmt.Errorf("bundled schemas: %w", err)
+				return
+			}
+		}
+	})
+	return bundled
+}
+
+func (b *bundledSchemas) SourceSchema(ctx context.Context, typ string) (json.RawMessage, error) {
+	return b.sources[typ], bundledSchemasErr
+}
+
+func (b *bundledSchemas) DestinationSchema(ctx context.Context, typ string) (json.RawMessage, error) {
+	return b.destinations[typ], bundledSchemasErr
+}
+
+// SourceSchema returns the config schema of a source type, from its definition. It implements SchemaProvider.
+func (s *definitions) SourceSchema(ctx context.Context, typ string) (json.RawMessage, error) {
+	definition, err := s.Source(ctx, typ)
+	if err != nil {
+		return nil, err
+	}
+	return definition.ConfigSchema, nil
+}
+
+// DestinationSchema returns the config schema of a destination type, from its definition. It implements SchemaProvider.
+func (s *definitions) DestinationSchema(ctx context.Context, typ string) (json.RawMessage, error) {
+	definition, err := s.Destination(ctx, typ)
+	if err != nil {
+		return nil, err
+	}
+	return definition.ConfigSchema, nil
+}
+
+// configValidator validates configs against the schemas of a provider, which are fetched and compiled once per type.
+type configValidator struct {
+	provider SchemaProvider
+
+	mu      sync.Mutex
+	schemas map[string]*jsonschema.Schema
+}
+
+func newConfigValidator(provider SchemaProvider) *configValidator {
+	return &configValidator{provider: provider, schemas: map[string]*jsonschema.Schema{}}
+}
+
+func (v *configValidator) schema(ctx context.Context, kind, typ string) (*jsonschema.Schema, error) {
+	key := kind + "/" + typ
+
+	v.mu.Lock()
+	schema, ok := v.schemas[key]
+	v.mu.Unlock()
+	if ok {
+		return schema, nil
+	}
+
+	var raw json.RawMessage
+	var err error
+	if kind == "source" {
+		raw, err = v.provider.SourceSchema(ctx, typ)
+	} else {
+		raw, err = v.provider.DestinationSchema(ctx, typ)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config schema of %s type '%s': %w", kind, typ, err)
+	}
+
+	if len(raw) > 0 {
+		if schema, err = jsonschema.Compile(raw); err != nil {
+			return nil, fmt.Errorf("config schema of %s type '%s': %w", kind, typ, err)
+		}
+	}
+
+	v.mu.Lock()
+	v.schemas[key] = schema
+	v.mu.Unlock()
+
+	return schema, nil
+}
+
+func (v *configValidator) validate(ctx context.Context, kind, typ string, config json.RawMessage) error {
+	schema, err := v.schema(ctx, kind, typ)
+	if err != nil || schema == nil {
+		return err
+	}
+
+	return validateConfig(schema, typ, config)
+}
+
+// validateConfig validates the config of a source or destination before it is sent, if config validation is enabled.
+func (c *Client) validateConfig(ctx context.Context, kind, typ string, config json.RawMessage) error {
+	if c.configValidator == nil {
+		return nil
+	}
+	return c.configValidator.validate(ctx, kind, typ, config)
+}
@@ -0,0 +1,155 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidationBundledSchemas(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/destinations", `{
+					"name": "warehouse",
+					"type": "POSTGRES",
+					"enabled": true,
+					"config": {"host": "localhost", "port": "5432", "database": "db", "user": "rudder", "password": "secret"}
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "destination": { "id": "some-id" } }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "PUT", "https://api.rudderstack.com/v2/destinations/other-id", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "destination": { "id": "other-id" } }`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient), client.WithConfigValidation(client.BundledSchemas()))
+	require.NoError(t, err)
+
+	// invalid configs are rejected before any request is sent, with all their violations
+	_, err = c.Destinations.Create(ctx, &client.Destination{
+		Name:      "warehouse",
+		Type:      "POSTGRES",
+		IsEnabled: true,
+		Config:    json.RawMessage(`{"host": "", "port": 5432, "sslMode": "prefer"}`),
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, client.ErrValidation))
+
+	var validationErr *client.ConfigValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "POSTGRES", validationErr.Type)
+	assert.Equal(t, []client.FieldError{
+		{Field: "config.database", Message: "is required", Code: "required"},
+		{Field: "config.host", Message: "must be at least 1 characters long", Code: "minLength"},
+		{Field: "config.password", Message: "is required", Code: "required"},
+		{Field: "config.port", Message: "must be of type string", Code: "type"},
+		{Field: "config.sslMode", Message: `must be one of ["disable", "require", "verify-ca"]`, Code: "enum"},
+		{Field: "config.user", Message: "is required", Code: "required"},
+	}, validationErr.ValidationErrors())
+
+	_, err = c.Sources.Update(ctx, &client.Source{
+		ID:     "some-id",
+		Type:   "HTTP",
+		Config: json.RawMessage(`{"eventUploadTS": "yesterday"}`),
+	})
+	assert.EqualError(t, err, "invalid config for type 'HTTP': config.eventUploadTS: must be of type integer")
+
+	// valid configs, and configs of types without a bundled schema, are sent
+	_, err = c.Destinations.Create(ctx, &client.Destination{
+		Name:      "warehouse",
+		Type:      "POSTGRES",
+		IsEnabled: true,
+		Config:    json.RawMessage(`{"host": "localhost", "port": "5432", "database": "db", "user": "rudder", "password": "secret"}`),
+	})
+	require.NoError(t, err)
+
+	_, err = c.Destinations.Update(ctx, &client.Destination{
+		ID:     "other-id",
+		Type:   "SOME_TYPE",
+		Config: json.RawMessage(`{"any": "thing"}`),
+	})
+	require.NoError(t, err)
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestBundledSchemas(t *testing.T) {
+	ctx := context.Background()
+	schemas := client.BundledSchemas()
+
+	for _, typ := range []string{"POSTGRES", "WEBHOOK", "S3", "BQ", "SNOWFLAKE", "RS"} {
+		schema, err := schemas.DestinationSchema(ctx, typ)
+		require.NoError(t, err)
+		assert.True(t, json.Valid(schema), typ)
+	}
+
+	for _, typ := range []string{"HTTP", "Javascript", "Android", "iOS"} {
+		schema, err := schemas.SourceSchema(ctx, typ)
+		require.NoError(t, err)
+		assert.True(t, json.Valid(schema), typ)
+	}
+
+	schema, err := schemas.DestinationSchema(ctx, "SOME_TYPE")
+	require.NoError(t, err)
+	assert.Nil(t, schema)
+}
+
+func TestConfigValidationDefinitions(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []testutils.Call{
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/definitions/destinations/POSTGRES", "")
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{ "definition": ` + postgresDefinition + ` }`,
+		},
+		{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "GET", "https://api.rudderstack.com/v2/definitions/sources/UNKNOWN", "")
+			},
+			ResponseStatus: 404,
+			ResponseBody:   `{ "error": "definition not found", "code": "not_found" }`,
+		},
+	}
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient), client.WithDefinitionsConfigValidation())
+	require.NoError(t, err)
+
+	// the schema is fetched once
+	for i := 0; i < 2; i++ {
+		_, err = c.Destinations.Create(ctx, &client.Destination{Type: "POSTGRES", Config: json.RawMessage(`{"host": "localhost"}`)})
+		assert.EqualError(t, err, "invalid config for type 'POSTGRES': config.database: is required; config.port: is required")
+	}
+
+	_, err = c.Sources.Create(ctx, &client.Source{Type: "UNKNOWN"})
+	assert.True(t, errors.Is(err, client.ErrNotFound))
+
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestWithConfigValidationNilProvider(t *testing.T) {
+	_, err := client.New("some-access-token", client.WithConfigValidation(nil))
+	assert.Equal(t, client.ErrInvalidSchemaProvider, err)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	accessTokenEnv = "RUDDERSTACK_API_ACCESS_TOKEN"
+	baseURLEnv     = "RUDDERSTACK_API_HOST"
+)
+
+// config holds the settings needed to connect to the API.
+type config struct {
+	AccessToken string `yaml:"accessToken"`
+	BaseURL     string `yaml:"baseURL"`
+}
+
+// defaultConfigPath returns the path of the configuration file used when none is given.
+func defaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".rudder", "config.yaml")
+}
+
+// loadConfig resolves the settings from, in order of precedence, command line flags,
+// environment variables and the configuration file.
+func loadConfig(path string, explicitPath bool, flags config, getenv func(string) string) (*config, error) {
+	cfg := &config{}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(data, cfg); err != nil {
+				return nil, fmt.Errorf("could not parse config file %s: %w", path, err)
+			}
+		case os.IsNotExist(err) && !explicitPath:
+			// the default configuration file is optional
+		default:
+			return nil, err
+		}
+	}
+
+	if token := getenv(accessTokenEnv); token != "" {
+		cfg.AccessToken = token
+	}
+	if baseURL := getenv(baseURLEnv); baseURL != "" {
+		cfg.BaseURL = baseURL
+	}
+
+	if flags.AccessToken != "" {
+		cfg.AccessToken = flags.AccessToken
+	}
+	if flags.BaseURL != "" {
+		cfg.BaseURL = flags.BaseURL
+	}
+
+	if cfg.AccessToken == "" {
+		return nil, fmt.Errorf("no access token: set %s, use -token or add accessToken to %s", accessTokenEnv, path)
+	}
+
+	return cfg, nil
+}
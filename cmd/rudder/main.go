@@ -0,0 +1,195 @@
+// Command rudder inspects and manages the sources, destinations and connections of a RudderStack workspace.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+)
+
+const usage = `Usage: rudder <resource> <command> [flags] [id]
+
+Resources:
+  sources, destinations, connections
+
+Commands:
+  list                 list all resources
+  get <id>             show a resource
+  create -f <file>     create a resource from a JSON or YAML file ("-" reads from stdin)
+  update <id> -f <file>
+                       update a resource from a JSON or YAML file ("-" reads from stdin); fields
+                       missing from the file keep their current values
+  delete <id>          delete a resource
+
+The access token is read from the -token flag, the ` + accessTokenEnv + ` environment variable
+or the accessToken field of the config file, in this order. The API base URL can be set the same way,
+with -base-url, ` + baseURLEnv + ` or the baseURL field.
+
+Flags:
+`
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	os.Exit(run(ctx, os.Args[1:], os.Getenv, os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(ctx context.Context, args []string, getenv func(string) string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("rudder", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+
+	var flags config
+	configPath := fs.String("config", "", "path of the config file (default "+defaultConfigPath()+")")
+	fs.StringVar(&flags.AccessToken, "token", "", "API access token")
+	fs.StringVar(&flags.BaseURL, "base-url", "", "API base URL (default "+client.BASE_URL_V2+")")
+	output := fs.String("o", "table", "output format: "+strings.Join(printerNames(), ", "))
+	file := fs.String("f", "", "input file for create and update")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	if len(positional) < 2 {
+		fs.Usage()
+		return 2
+	}
+
+	r, ok := resources[positional[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown resource '%s'\n", positional[0])
+		return 2
+	}
+
+	printResult, ok := printers[*output]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown output format '%s'\n", *output)
+		return 2
+	}
+
+	path, explicitPath := *configPath, *configPath != ""
+	if !explicitPath {
+		path = defaultConfigPath()
+	}
+	cfg, err := loadConfig(path, explicitPath, flags, getenv)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	options := []client.Option{client.WithUserAgent("rudder-cli/1.0.0")}
+	if cfg.BaseURL != "" {
+		options = append(options, client.WithBaseURL(cfg.BaseURL))
+	}
+	c, err := client.New(cfg.AccessToken, options...)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	command, ids := positional[1], positional[2:]
+	readInput := func() ([]byte, error) {
+		switch *file {
+		case "":
+			return nil, fmt.Errorf("%s requires an input file, use -f", command)
+		case "-":
+			return ioutil.ReadAll(stdin)
+		default:
+			return ioutil.ReadFile(*file)
+		}
+	}
+
+	var items []interface{}
+	single := true
+	switch {
+	case command == "list" && len(ids) == 0:
+		items, err = r.list(ctx, c)
+		single = false
+
+	case command == "get" && len(ids) == 1:
+		var item interface{}
+		if item, err = r.get(ctx, c, ids[0]); err == nil {
+			items = []interface{}{item}
+		}
+
+	case command == "create" && len(ids) == 0:
+		var input []byte
+		if input, err = readInput(); err == nil {
+			var item interface{}
+			if item, err = r.create(ctx, c, input); err == nil {
+				items = []interface{}{item}
+			}
+		}
+
+	case command == "update" && len(ids) == 1:
+		var input []byte
+		if input, err = readInput(); err == nil {
+			var item interface{}
+			if item, err = r.update(ctx, c, ids[0], input); err == nil {
+				items = []interface{}{item}
+			}
+		}
+
+	case command == "delete" && len(ids) == 1:
+		if err = r.delete(ctx, c, ids[0]); err == nil {
+			fmt.Fprintf(stdout, "deleted %s\n", ids[0])
+			return 0
+		}
+
+	default:
+		fs.Usage()
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	if err := printResult(stdout, r, items, single); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+// parseInterspersed parses flags which might appear before, between or after positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+func printerNames() []string {
+	var names []string
+	for name := range printers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
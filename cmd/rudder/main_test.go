@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "Bearer some-access-token", req.Header.Get("Authorization"))
+
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/v2/sources":
+			w.Write([]byte(`{"sources": [{"id": "id-1", "name": "name-1", "type": "HTTP", "enabled": true, "writeKey": "key-1", "config": {}}], "paging": {"total": 1}}`))
+		case req.Method == "GET" && req.URL.Path == "/v2/destinations/id-1":
+			w.Write([]byte(`{"destination": {"id": "id-1", "name": "name-1", "type": "WEBHOOK", "enabled": false, "config": {"webhookUrl": "https://example.com"}}}`))
+		case req.Method == "POST" && req.URL.Path == "/v2/connections":
+			body, _ := ioutil.ReadAll(req.Body)
+			assert.JSONEq(t, `{"sourceId": "src-1", "destinationId": "dst-1", "enabled": true}`, string(body))
+			w.Write([]byte(`{"connection": {"id": "id-1", "sourceId": "src-1", "destinationId": "dst-1", "enabled": true}}`))
+		case req.Method == "GET" && req.URL.Path == "/v2/connections/id-1":
+			w.Write([]byte(`{"connection": {"id": "id-1", "sourceId": "src-1", "destinationId": "dst-1", "enabled": true}}`))
+		case req.Method == "PUT" && req.URL.Path == "/v2/connections/id-1":
+			body, _ := ioutil.ReadAll(req.Body)
+			assert.JSONEq(t, `{"sourceId": "src-1", "destinationId": "dst-2", "enabled": true}`, string(body))
+			w.Write([]byte(`{"connection": {"id": "id-1", "sourceId": "src-1", "destinationId": "dst-2", "enabled": true}}`))
+		case req.Method == "DELETE" && req.URL.Path == "/v2/sources/id-1":
+			w.WriteHeader(204)
+		default:
+			w.WriteHeader(404)
+			w.Write([]byte(`{"error": "not found", "code": "not_found"}`))
+		}
+	}))
+}
+
+func runTest(t *testing.T, server *httptest.Server, stdin string, args ...string) (int, string, string) {
+	env := map[string]string{
+		accessTokenEnv: "some-access-token",
+		baseURLEnv:     server.URL + "/v2",
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, func(key string) string { return env[key] }, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunListTable(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	code, stdout, stderr := runTest(t, server, "", "sources", "list")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "ID    NAME    TYPE  ENABLED  WRITE KEY\nid-1  name-1  HTTP  true     key-1\n", stdout)
+}
+
+func TestRunGetJSON(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	code, stdout, stderr := runTest(t, server, "", "destinations", "get", "id-1", "-o", "json")
+	require.Equal(t, 0, code, stderr)
+	assert.JSONEq(t, `{"id": "id-1", "name": "name-1", "type": "WEBHOOK", "enabled": false, "config": {"webhookUrl": "https://example.com"}}`, stdout)
+}
+
+func TestRunCreateYAML(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	input := "sourceId: src-1\ndestinationId: dst-1\nenabled: true\n"
+	code, stdout, stderr := runTest(t, server, input, "connections", "create", "-f", "-", "-o", "yaml")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "destinationId: dst-1\nenabled: true\nid: id-1\nsourceId: src-1\n", stdout)
+}
+
+func TestRunUpdateKeepsMissingFields(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	code, stdout, stderr := runTest(t, server, "destinationId: dst-2\n", "connections", "update", "id-1", "-f", "-", "-o", "json")
+	require.Equal(t, 0, code, stderr)
+	assert.JSONEq(t, `{"id": "id-1", "sourceId": "src-1", "destinationId": "dst-2", "enabled": true}`, stdout)
+}
+
+func TestRunDelete(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	code, stdout, stderr := runTest(t, server, "", "sources", "delete", "id-1")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "deleted id-1\n", stdout)
+}
+
+func TestRunAPIError(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	code, _, stderr := runTest(t, server, "", "sources", "get", "unknown")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "not_found")
+}
+
+func TestRunUsage(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	code, _, _ := runTest(t, server, "", "sources")
+	assert.Equal(t, 2, code)
+
+	code, _, stderr := runTest(t, server, "", "workspaces", "list")
+	assert.Equal(t, 2, code)
+	assert.Equal(t, "unknown resource 'workspaces'\n", stderr)
+
+	code, _, _ = runTest(t, server, "", "sources", "get")
+	assert.Equal(t, 2, code)
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("accessToken: file-token\nbaseURL: https://file.example.com\n"), 0600))
+
+	noEnv := func(string) string { return "" }
+
+	cfg, err := loadConfig(path, true, config{}, noEnv)
+	require.NoError(t, err)
+	assert.Equal(t, &config{AccessToken: "file-token", BaseURL: "https://file.example.com"}, cfg)
+
+	cfg, err = loadConfig(path, true, config{}, func(key string) string {
+		return map[string]string{accessTokenEnv: "env-token"}[key]
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &config{AccessToken: "env-token", BaseURL: "https://file.example.com"}, cfg)
+
+	cfg, err = loadConfig(path, true, config{AccessToken: "flag-token"}, noEnv)
+	require.NoError(t, err)
+	assert.Equal(t, "flag-token", cfg.AccessToken)
+
+	_, err = loadConfig(filepath.Join(t.TempDir(), "missing.yaml"), false, config{}, noEnv)
+	assert.Error(t, err)
+
+	_, err = loadConfig(filepath.Join(t.TempDir(), "missing.yaml"), true, config{AccessToken: "flag-token"}, noEnv)
+	assert.Error(t, err)
+}
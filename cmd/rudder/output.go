@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// printer writes the result of a command in one of the supported formats.
+type printer func(w io.Writer, r *resource, items []interface{}, single bool) error
+
+var printers = map[string]printer{
+	"table": printTable,
+	"json":  printJSON,
+	"yaml":  printYAML,
+}
+
+func printTable(w io.Writer, r *resource, items []interface{}, _ bool) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(r.columns, "\t"))
+	for _, item := range items {
+		fmt.Fprintln(tw, strings.Join(r.row(item), "\t"))
+	}
+	return tw.Flush()
+}
+
+func printJSON(w io.Writer, _ *resource, items []interface{}, single bool) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if single {
+		return encoder.Encode(items[0])
+	}
+	return encoder.Encode(items)
+}
+
+func printYAML(w io.Writer, _ *resource, items []interface{}, single bool) error {
+	var value interface{} = items
+	if single {
+		value = items[0]
+	}
+
+	// go through JSON, so that field names and raw configurations are rendered as in the API
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(generic); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// decodeInput decodes a resource given in JSON or YAML format into v.
+func decodeInput(data []byte, v interface{}) error {
+	var generic interface{}
+	if err := yaml.Unmarshal(data, &generic); err != nil {
+		return fmt.Errorf("could not parse input: %w", err)
+	}
+
+	jsonData, err := json.Marshal(generic)
+	if err != nil {
+		return fmt.Errorf("could not parse input: %w", err)
+	}
+
+	if err := json.Unmarshal(jsonData, v); err != nil {
+		return fmt.Errorf("could not parse input: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+)
+
+// resource describes how the commands operate on a type of resource.
+type resource struct {
+	columns []string
+	row     func(item interface{}) []string
+
+	list   func(ctx context.Context, c *client.Client) ([]interface{}, error)
+	get    func(ctx context.Context, c *client.Client, id string) (interface{}, error)
+	create func(ctx context.Context, c *client.Client, input []byte) (interface{}, error)
+	// update overlays the input on the current resource, so that fields missing from the input are left untouched.
+	update func(ctx context.Context, c *client.Client, id string, input []byte) (interface{}, error)
+	delete func(ctx context.Context, c *client.Client, id string) error
+}
+
+var resources = map[string]*resource{
+	"sources": {
+		columns: []string{"ID", "NAME", "TYPE", "ENABLED", "WRITE KEY"},
+		row: func(item interface{}) []string {
+			s := item.(*client.Source)
+			return []string{s.ID, s.Name, s.Type, strconv.FormatBool(s.IsEnabled), s.WriteKey}
+		},
+		list: func(ctx context.Context, c *client.Client) ([]interface{}, error) {
+			items := []interface{}{}
+			it := c.Sources.All(ctx)
+			for it.Next() {
+				source := it.Source()
+				items = append(items, &source)
+			}
+			return items, it.Err()
+		},
+		get: func(ctx context.Context, c *client.Client, id string) (interface{}, error) {
+			return c.Sources.Get(ctx, id)
+		},
+		create: func(ctx context.Context, c *client.Client, input []byte) (interface{}, error) {
+			source := &client.Source{}
+			if err := decodeInput(input, source); err != nil {
+				return nil, err
+			}
+			return c.Sources.Create(ctx, source)
+		},
+		update: func(ctx context.Context, c *client.Client, id string, input []byte) (interface{}, error) {
+			source, err := c.Sources.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if err := decodeInput(input, source); err != nil {
+				return nil, err
+			}
+			source.ID = id
+			return c.Sources.Update(ctx, source)
+		},
+		delete: func(ctx context.Context, c *client.Client, id string) error {
+			return c.Sources.Delete(ctx, id)
+		},
+	},
+
+	"destinations": {
+		columns: []string{"ID", "NAME", "TYPE", "ENABLED"},
+		row: func(item interface{}) []string {
+			d := item.(*client.Destination)
+			return []string{d.ID, d.Name, d.Type, strconv.FormatBool(d.IsEnabled)}
+		},
+		list: func(ctx context.Context, c *client.Client) ([]interface{}, error) {
+			items := []interface{}{}
+			it := c.Destinations.All(ctx)
+			for it.Next() {
+				destination := it.Destination()
+				items = append(items, &destination)
+			}
+			return items, it.Err()
+		},
+		get: func(ctx context.Context, c *client.Client, id string) (interface{}, error) {
+			return c.Destinations.Get(ctx, id)
+		},
+		create: func(ctx context.Context, c *client.Client, input []byte) (interface{}, error) {
+			destination := &client.Destination{}
+			if err := decodeInput(input, destination); err != nil {
+				return nil, err
+			}
+			return c.Destinations.Create(ctx, destination)
+		},
+		update: func(ctx context.Context, c *client.Client, id string, input []byte) (interface{}, error) {
+			destination, err := c.Destinations.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if err := decodeInput(input, destination); err != nil {
+				return nil, err
+			}
+			destination.ID = id
+			return c.Destinations.Update(ctx, destination)
+		},
+		delete: func(ctx context.Context, c *client.Client, id string) error {
+			return c.Destinations.Delete(ctx, id)
+		},
+	},
+
+	"connections": {
+		columns: []string{"ID", "SOURCE", "DESTINATION", "ENABLED"},
+		row: func(item interface{}) []string {
+			conn := item.(*client.Connection)
+			return []string{conn.ID, conn.SourceID, conn.DestinationID, strconv.FormatBool(conn.IsEnabled)}
+		},
+		list: func(ctx context.Context, c *client.Client) ([]interface{}, error) {
+			items := []interface{}{}
+			it := c.Connections.All(ctx)
+			for it.Next() {
+				connection := it.Connection()
+				items = append(items, &connection)
+			}
+			return items, it.Err()
+		},
+		get: func(ctx context.Context, c *client.Client, id string) (interface{}, error) {
+			return c.Connections.Get(ctx, id)
+		},
+		create: func(ctx context.Context, c *client.Client, input []byte) (interface{}, error) {
+			connection := &client.Connection{}
+			if err := decodeInput(input, connection); err != nil {
+				return nil, err
+			}
+			return c.Connections.Create(ctx, connection)
+		},
+		update: func(ctx context.Context, c *client.Client, id string, input []byte) (interface{}, error) {
+			connection, err := c.Connections.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if err := decodeInput(input, connection); err != nil {
+				return nil, err
+			}
+			connection.ID = id
+			return c.Connections.Update(ctx, connection)
+		},
+		delete: func(ctx context.Context, c *client.Client, id string) error {
+			return c.Connections.Delete(ctx, id)
+		},
+	},
+}
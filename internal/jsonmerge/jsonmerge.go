@@ -0,0 +1,47 @@
+// Package jsonmerge implements JSON merge patches, as defined by RFC 7386.
+package jsonmerge
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Patch applies a merge patch to a JSON document: the fields of patch objects are set recursively on the document,
+// null values deleting fields, and any other patch value replacing the document. An empty document is treated as
+// null.
+func Patch(document, patch []byte) ([]byte, error) {
+	var target interface{}
+	if len(document) > 0 {
+		if err := json.Unmarshal(document, &target); err != nil {
+			return nil, fmt.Errorf("invalid document: %w", err)
+		}
+	}
+
+	var p interface{}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+
+	return json.Marshal(merge(target, p))
+}
+
+func merge(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = merge(targetObject[key], value)
+	}
+	return targetObject
+}
@@ -0,0 +1,45 @@
+package jsonmerge_test
+
+import (
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/internal/jsonmerge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatch(t *testing.T) {
+	// test cases of RFC 7386, appendix A
+	for _, tc := range []struct {
+		document, patch, expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		{``, `{"a":1}`, `{"a":1}`},
+	} {
+		patched, err := jsonmerge.Patch([]byte(tc.document), []byte(tc.patch))
+		require.NoError(t, err)
+		assert.JSONEq(t, tc.expected, string(patched), "%s + %s", tc.document, tc.patch)
+	}
+}
+
+func TestPatchInvalid(t *testing.T) {
+	_, err := jsonmerge.Patch([]byte(`{`), []byte(`{}`))
+	assert.Error(t, err)
+
+	_, err = jsonmerge.Patch([]byte(`{}`), []byte(`{`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,439 @@
+// Package jsonschema validates JSON documents against the subset of JSON Schema used by the config schemas of
+// source and destination definitions: types, enums and constants, object properties, array items, string and
+// number bounds, patterns, combinators, conditionals and local references. Patterns which the regexp package cannot
+// compile, e.g. ECMAScript lookaheads, are not supported and are ignored.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Violation is a failure of a document to match a schema.
+type Violation struct {
+	// Path locates the invalid value in the document, e.g. "headers[0].name", or is empty for the document itself.
+	Path string
+	// Keyword is the schema keyword which failed, e.g. "required" or "type".
+	Keyword string
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return v.Path + ": " + v.Message
+}
+
+// Schema is a compiled JSON Schema.
+type Schema struct {
+	root map[string]interface{}
+	// patterns holds the compiled patterns of the schema, by source, leaving out the unsupported ones
+	patterns map[string]*regexp.Regexp
+}
+
+// Compile parses a JSON Schema.
+func Compile(schema []byte) (*Schema, error) {
+	var root interface{}
+	if err := decode(schema, &root); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	switch root := root.(type) {
+	case map[string]interface{}:
+		patterns := map[string]*regexp.Regexp{}
+		compilePatterns(root, patterns)
+		return &Schema{root: root, patterns: patterns}, nil
+	case bool:
+		if root {
+			return &Schema{root: map[string]interface{}{}}, nil
+		}
+		return &Schema{root: map[string]interface{}{"not": map[string]interface{}{}}}, nil
+	default:
+		return nil, fmt.Errorf("invalid schema: must be an object or a boolean")
+	}
+}
+
+// compilePatterns compiles the patterns found in node and its children into patterns.
+func compilePatterns(node interface{}, patterns map[string]*regexp.Regexp) {
+	switch node := node.(type) {
+	case map[string]interface{}:
+		for key, child := range node {
+			if pattern, ok := child.(string); ok && key == "pattern" {
+				if re, err := regexp.Compile(pattern); err == nil {
+					patterns[pattern] = re
+				}
+				continue
+			}
+			compilePatterns(child, patterns)
+		}
+	case []interface{}:
+		for _, child := range node {
+			compilePatterns(child, patterns)
+		}
+	}
+}
+
+// Validate validates a JSON document, returning every violation found, sorted by path.
+func (s *Schema) Validate(document []byte) ([]Violation, error) {
+	var value interface{}
+	if err := decode(document, &value); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+
+	v := &validator{root: s.root, patterns: s.patterns}
+	v.validate(s.root, value, "")
+
+	sort.SliceStable(v.violations, func(i, j int) bool {
+		return v.violations[i].Path < v.violations[j].Path
+	})
+	return v.violations, nil
+}
+
+func decode(data []byte, value interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(value)
+}
+
+type validator struct {
+	root       map[string]interface{}
+	patterns   map[string]*regexp.Regexp
+	violations []Violation
+	// depth guards against cyclic references
+	depth int
+}
+
+func (v *validator) fail(path, keyword, format string, args ...interface{}) {
+	v.violations = append(v.violations, Violation{Path: path, Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+}
+
+// matches reports whether value matches schema, without recording violations.
+func (v *validator) matches(schema interface{}, value interface{}, path string) bool {
+	sub := &validator{root: v.root, patterns: v.patterns, depth: v.depth}
+	sub.validate(schema, value, path)
+	return len(sub.violations) == 0
+}
+
+func (v *validator) validate(schema interface{}, value interface{}, path string) {
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		if b, ok := schema.(bool); ok && !b {
+			v.fail(path, "false", "is not allowed")
+		}
+		return
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		v.depth++
+		defer func() { v.depth-- }()
+		if v.depth > 32 {
+			v.fail(path, "$ref", "too many nested references")
+			return
+		}
+
+		target, err := v.resolve(ref)
+		if err != nil {
+			v.fail(path, "$ref", "%s", err)
+			return
+		}
+		v.validate(target, value, path)
+	}
+
+	if t, ok := s["type"]; ok && !matchesType(t, value) {
+		v.fail(path, "type", "must be of type %s", typeNames(t))
+		// other keywords would only report the same mismatch
+		return
+	}
+
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if equal(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(path, "enum", "must be one of %s", formatValues(enum))
+		}
+	}
+
+	if c, ok := s["const"]; ok && !equal(c, value) {
+		v.fail(path, "const", "must be %s", formatValue(c))
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(s, value, path)
+	case []interface{}:
+		v.validateArray(s, value, path)
+	case string:
+		v.validateString(s, value, path)
+	case json.Number:
+		v.validateNumber(s, value, path)
+	}
+
+	if all, ok := s["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			v.validate(sub, value, path)
+		}
+	}
+
+	if anyOf, ok := s["anyOf"].([]interface{}); ok {
+		matched := false
+		for _, sub := range anyOf {
+			if v.matches(sub, value, path) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.fail(path, "anyOf", "must match at least one of the allowed schemas")
+		}
+	}
+
+	if one, ok := s["oneOf"].([]interface{}); ok {
+		matched := 0
+		for _, sub := range one {
+			if v.matches(sub, value, path) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			v.fail(path, "oneOf", "must match exactly one of the allowed schemas, matches %d", matched)
+		}
+	}
+
+	if not, ok := s["not"]; ok && v.matches(not, value, path) {
+		v.fail(path, "not", "must not match the disallowed schema")
+	}
+
+	if cond, ok := s["if"]; ok {
+		if v.matches(cond, value, path) {
+			if then, ok := s["then"]; ok {
+				v.validate(then, value, path)
+			}
+		} else if els, ok := s["else"]; ok {
+			v.validate(els, value, path)
+		}
+	}
+}
+
+func (v *validator) validateObject(s map[string]interface{}, value map[string]interface{}, path string) {
+	if required, ok := s["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, ok := value[name]; !ok {
+				v.fail(join(path, name), "required", "is required")
+			}
+		}
+	}
+
+	properties, _ := s["properties"].(map[string]interface{})
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if property, ok := properties[name]; ok {
+			v.validate(property, value[name], join(path, name))
+			continue
+		}
+
+		switch additional := s["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.fail(join(path, name), "additionalProperties", "is not allowed")
+			}
+		case map[string]interface{}:
+			v.validate(additional, value[name], join(path, name))
+		}
+	}
+}
+
+func (v *validator) validateArray(s map[string]interface{}, value []interface{}, path string) {
+	if min, ok := integer(s["minItems"]); ok && len(value) < min {
+		v.fail(path, "minItems", "must have at least %d items", min)
+	}
+	if max, ok := integer(s["maxItems"]); ok && len(value) > max {
+		v.fail(path, "maxItems", "must have at most %d items", max)
+	}
+
+	if items, ok := s["items"]; ok {
+		for i, item := range value {
+			v.validate(items, item, path+"["+strconv.Itoa(i)+"]")
+		}
+	}
+}
+
+func (v *validator) validateString(s map[string]interface{}, value string, path string) {
+	length := len([]rune(value))
+	if min, ok := integer(s["minLength"]); ok && length < min {
+		v.fail(path, "minLength", "must be at least %d characters long", min)
+	}
+	if max, ok := integer(s["maxLength"]); ok && length > max {
+		v.fail(path, "maxLength", "must be at most %d characters long", max)
+	}
+
+	if pattern, ok := s["pattern"].(string); ok {
+		// unsupported patterns are missing, and are ignored
+		if re, ok := v.patterns[pattern]; ok && !re.MatchString(value) {
+			v.fail(path, "pattern", "must match the pattern %q", pattern)
+		}
+	}
+}
+
+func (v *validator) validateNumber(s map[string]interface{}, value json.Number, path string) {
+	n, err := value.Float64()
+	if err != nil {
+		return
+	}
+
+	if min, ok := number(s["minimum"]); ok && n < min {
+		v.fail(path, "minimum", "must be greater than or equal to %v", min)
+	}
+	if max, ok := number(s["maximum"]); ok && n > max {
+		v.fail(path, "maximum", "must be less than or equal to %v", max)
+	}
+	if min, ok := number(s["exclusiveMinimum"]); ok && n <= min {
+		v.fail(path, "exclusiveMinimum", "must be greater than %v", min)
+	}
+	if max, ok := number(s["exclusiveMaximum"]); ok && n >= max {
+		v.fail(path, "exclusiveMaximum", "must be less than %v", max)
+	}
+}
+
+// resolve resolves a local reference, e.g. "#/definitions/header".
+func (v *validator) resolve(ref string) (interface{}, error) {
+	if ref == "#" {
+		return v.root, nil
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported reference %q", ref)
+	}
+
+	var current interface{} = v.root
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unresolvable reference %q", ref)
+		}
+		if current, ok = object[token]; !ok {
+			return nil, fmt.Errorf("unresolvable reference %q", ref)
+		}
+	}
+	return current, nil
+}
+
+func matchesType(t interface{}, value interface{}) bool {
+	switch t := t.(type) {
+	case string:
+		return isType(t, value)
+	case []interface{}:
+		for _, name := range t {
+			if name, ok := name.(string); ok && isType(name, value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+func isType(name string, value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return name == "null"
+	case bool:
+		return name == "boolean"
+	case string:
+		return name == "string"
+	case []interface{}:
+		return name == "array"
+	case map[string]interface{}:
+		return name == "object"
+	case json.Number:
+		if name == "number" {
+			return true
+		}
+		if name == "integer" {
+			f, err := value.Float64()
+			return err == nil && f == math.Trunc(f)
+		}
+	}
+	return false
+}
+
+func typeNames(t interface{}) string {
+	if names, ok := t.([]interface{}); ok {
+		parts := make([]string, 0, len(names))
+		for _, name := range names {
+			parts = append(parts, fmt.Sprint(name))
+		}
+		return strings.Join(parts, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+// equal compares JSON values, numbers being compared by value rather than by representation.
+func equal(a, b interface{}) bool {
+	if an, ok := a.(json.Number); ok {
+		bn, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		af, aErr := an.Float64()
+		bf, bErr := bn.Float64()
+		return aErr == nil && bErr == nil && af == bf
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func integer(value interface{}) (int, bool) {
+	n, ok := number(value)
+	return int(n), ok
+}
+
+func number(value interface{}) (float64, bool) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	f, err := n.Float64()
+	return f, err == nil
+}
+
+func formatValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func formatValues(values []interface{}) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, formatValue(value))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,126 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/internal/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const schema = `{
+	"type": "object",
+	"required": ["host", "port"],
+	"properties": {
+		"host": { "type": "string", "minLength": 1 },
+		"port": { "type": "integer", "minimum": 1, "maximum": 65535 },
+		"sslMode": { "enum": ["disable", "require", "verify-ca"] },
+		"namespace": { "type": "string", "pattern": "^[a-z_]+$" },
+		"headers": {
+			"type": "array",
+			"maxItems": 2,
+			"items": { "$ref": "#/definitions/header" }
+		},
+		"useSSH": { "type": "boolean" }
+	},
+	"additionalProperties": false,
+	"if": { "properties": { "useSSH": { "const": true } }, "required": ["useSSH"] },
+	"then": { "required": ["sshHost"], "properties": { "sshHost": { "type": "string" } } },
+	"definitions": {
+		"header": {
+			"type": "object",
+			"required": ["to", "from"],
+			"properties": { "to": { "type": "string" }, "from": { "type": "string" } }
+		}
+	}
+}`
+
+func TestValidate(t *testing.T) {
+	s, err := jsonschema.Compile([]byte(schema))
+	require.NoError(t, err)
+
+	violations, err := s.Validate([]byte(`{"host": "localhost", "port": 5432, "sslMode": "require", "headers": [{"to": "a", "from": "b"}]}`))
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	violations, err = s.Validate([]byte(`{
+		"host": "",
+		"port": 5432.5,
+		"sslMode": "prefer",
+		"namespace": "Public",
+		"headers": [{"to": "a"}, {"to": 1, "from": "b"}, {"to": "c", "from": "d"}],
+		"useSSH": true,
+		"other": 1
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, []jsonschema.Violation{
+		{Path: "headers", Keyword: "maxItems", Message: "must have at most 2 items"},
+		{Path: "headers[0].from", Keyword: "required", Message: "is required"},
+		{Path: "headers[1].to", Keyword: "type", Message: "must be of type string"},
+		{Path: "host", Keyword: "minLength", Message: "must be at least 1 characters long"},
+		{Path: "namespace", Keyword: "pattern", Message: `must match the pattern "^[a-z_]+$"`},
+		{Path: "other", Keyword: "additionalProperties", Message: "is not allowed"},
+		{Path: "port", Keyword: "type", Message: "must be of type integer"},
+		{Path: "sshHost", Keyword: "required", Message: "is required"},
+		{Path: "sslMode", Keyword: "enum", Message: `must be one of ["disable", "require", "verify-ca"]`},
+	}, violations)
+}
+
+func TestValidateCombinators(t *testing.T) {
+	s, err := jsonschema.Compile([]byte(`{
+		"type": ["object", "null"],
+		"properties": {
+			"mode": { "oneOf": [{ "const": "cloud" }, { "const": "device" }] },
+			"size": { "anyOf": [{ "type": "integer" }, { "type": "string", "pattern": "^[0-9]+$" }] },
+			"key": { "not": { "const": "" } }
+		}
+	}`))
+	require.NoError(t, err)
+
+	violations, err := s.Validate([]byte(`null`))
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	violations, err = s.Validate([]byte(`{"mode": "hybrid", "size": "ten", "key": ""}`))
+	require.NoError(t, err)
+	assert.Equal(t, []jsonschema.Violation{
+		{Path: "key", Keyword: "not", Message: "must not match the disallowed schema"},
+		{Path: "mode", Keyword: "oneOf", Message: "must match exactly one of the allowed schemas, matches 0"},
+		{Path: "size", Keyword: "anyOf", Message: "must match at least one of the allowed schemas"},
+	}, violations)
+
+	violations, err = s.Validate([]byte(`[]`))
+	require.NoError(t, err)
+	assert.Equal(t, []jsonschema.Violation{{Keyword: "type", Message: "must be of type object or null"}}, violations)
+	assert.Equal(t, "must be of type object or null", violations[0].String())
+}
+
+func TestCompileInvalid(t *testing.T) {
+	_, err := jsonschema.Compile([]byte(`[]`))
+	assert.EqualError(t, err, "invalid schema: must be an object or a boolean")
+
+	s, err := jsonschema.Compile([]byte(`false`))
+	require.NoError(t, err)
+	violations, err := s.Validate([]byte(`{}`))
+	require.NoError(t, err)
+	assert.Len(t, violations, 1)
+
+	_, err = s.Validate([]byte(`{`))
+	assert.Error(t, err)
+}
+
+func TestValidateUnsupportedPattern(t *testing.T) {
+	s, err := jsonschema.Compile([]byte(`{
+		"properties": {
+			"password": { "type": "string", "pattern": "^(?=.*[0-9]).{8,}$" },
+			"host": { "type": "string", "pattern": "^[a-z.]+$" }
+		}
+	}`))
+	require.NoError(t, err)
+
+	violations, err := s.Validate([]byte(`{"password": "secret", "host": "Example.com"}`))
+	require.NoError(t, err)
+	assert.Equal(t, []jsonschema.Violation{
+		{Path: "host", Keyword: "pattern", Message: `must match the pattern "^[a-z.]+$"`},
+	}, violations)
+}
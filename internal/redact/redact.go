@@ -0,0 +1,153 @@
+// Package redact removes secrets from HTTP headers and JSON documents, before they are logged or exported.
+package redact
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Placeholder replaces redacted values.
+const Placeholder = "[REDACTED]"
+
+// secretFragments are the fragments of field names which denote a secret, in lower case.
+var secretFragments = []string{
+	"password",
+	"secret",
+	"token",
+	"credential",
+	"apikey",
+	"api_key",
+	"accesskey",
+	"access_key",
+	"privatekey",
+	"private_key",
+	"writekey",
+}
+
+// IsSecret reports whether a field name denotes a secret value, e.g. "password" or "apiKey".
+func IsSecret(name string) bool {
+	name = strings.ToLower(name)
+	for _, fragment := range secretFragments {
+		if strings.Contains(name, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// Header returns a copy of the header with the Authorization value redacted.
+func Header(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+
+	redacted := header.Clone()
+	if redacted.Get("Authorization") != "" {
+		redacted.Set("Authorization", Placeholder)
+	}
+	return redacted
+}
+
+// JSON returns a copy of a JSON document in which the values of secret fields, at any depth, are replaced
+// with Placeholder. Documents which are not valid JSON are returned as they are.
+func JSON(data []byte) []byte {
+	if len(data) == 0 {
+		return data
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return data
+	}
+
+	if !walk(document) {
+		return data
+	}
+
+	redacted, err := json.Marshal(document)
+	if err != nil {
+		return data
+	}
+	return redacted
+}
+
+// StripJSON returns a copy of a JSON document without its secret fields, at any depth. Unlike JSON, which keeps
+// the fields with a placeholder value, it suits documents which are sent back to the API later. Documents which
+// are not valid JSON are returned as they are.
+func StripJSON(data []byte) []byte {
+	if len(data) == 0 {
+		return data
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return data
+	}
+
+	if !strip(document) {
+		return data
+	}
+
+	stripped, err := json.Marshal(document)
+	if err != nil {
+		return data
+	}
+	return stripped
+}
+
+// strip deletes the secret fields of a decoded JSON value in place, reporting whether any field was deleted.
+func strip(value interface{}) bool {
+	changed := false
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if IsSecret(key) {
+				delete(v, key)
+				changed = true
+				continue
+			}
+			if strip(field) {
+				changed = true
+			}
+		}
+
+	case []interface{}:
+		for _, item := range v {
+			if strip(item) {
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
+
+// walk replaces the secret fields of a decoded JSON value in place, reporting whether any field was changed.
+func walk(value interface{}) bool {
+	changed := false
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if IsSecret(key) {
+				v[key] = Placeholder
+				changed = true
+				continue
+			}
+			if walk(field) {
+				changed = true
+			}
+		}
+
+	case []interface{}:
+		for _, item := range v {
+			if walk(item) {
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,48 @@
+package redact_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/internal/redact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSecret(t *testing.T) {
+	for _, name := range []string{"password", "clientSecret", "accessToken", "credentials", "apiKey", "api_key", "accessKey", "privateKey", "writeKey"} {
+		assert.True(t, redact.IsSecret(name), name)
+	}
+
+	for _, name := range []string{"host", "port", "user", "namespace", "webhookUrl", "bucketName"} {
+		assert.False(t, redact.IsSecret(name), name)
+	}
+}
+
+func TestHeader(t *testing.T) {
+	header := http.Header{"Authorization": []string{"Bearer some-token"}, "User-Agent": []string{"some-agent"}}
+
+	redacted := redact.Header(header)
+	assert.Equal(t, http.Header{"Authorization": []string{"[REDACTED]"}, "User-Agent": []string{"some-agent"}}, redacted)
+	assert.Equal(t, "Bearer some-token", header.Get("Authorization"))
+	assert.Nil(t, redact.Header(nil))
+}
+
+func TestJSON(t *testing.T) {
+	assert.JSONEq(t,
+		`{"source": {"name": "some-name", "writeKey": "[REDACTED]", "config": {"host": "example.com", "password": "[REDACTED]", "headers": [{"apiKey": "[REDACTED]"}]}}}`,
+		string(redact.JSON([]byte(`{"source": {"name": "some-name", "writeKey": "key", "config": {"host": "example.com", "password": "secret", "headers": [{"apiKey": "key"}]}}}`))))
+
+	// unchanged and invalid documents are returned as they are
+	assert.Equal(t, `{"host": "example.com"}`, string(redact.JSON([]byte(`{"host": "example.com"}`))))
+	assert.Equal(t, `not json`, string(redact.JSON([]byte(`not json`))))
+}
+
+func TestStripJSON(t *testing.T) {
+	assert.JSONEq(t,
+		`{"host": "example.com", "headers": [{"name": "some-name"}]}`,
+		string(redact.StripJSON([]byte(`{"host": "example.com", "password": "secret", "headers": [{"name": "some-name", "apiKey": "key"}]}`))))
+
+	// unchanged and invalid documents are returned as they are
+	assert.Equal(t, `{"host": "example.com"}`, string(redact.StripJSON([]byte(`{"host": "example.com"}`))))
+	assert.Equal(t, `not json`, string(redact.StripJSON([]byte(`not json`))))
+}
@@ -16,6 +16,7 @@ type Call struct {
 	// Validate is an optional function that, if set, will validate an incoming request
 	Validate       func(req *http.Request) bool
 	ResponseStatus int
+	ResponseHeader http.Header
 	ResponseBody   string
 	ResponseError  error
 }
@@ -51,6 +52,7 @@ func (c *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
 
 	return &http.Response{
 		StatusCode: call.ResponseStatus,
+		Header:     call.ResponseHeader,
 		Body:       io.NopCloser(strings.NewReader(call.ResponseBody)),
 	}, call.ResponseError
 }
@@ -67,6 +69,10 @@ func ValidateRequest(t *testing.T, req *http.Request, method string, url string,
 		return false
 	}
 
+	if !assert.Equal(t, url, req.URL.String()) {
+		return false
+	}
+
 	if body != "" {
 		bodyBytes, err := ioutil.ReadAll(req.Body)
 		require.NoError(t, err)
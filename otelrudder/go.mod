@@ -0,0 +1,26 @@
+module github.com/rudderlabs/rudder-api-go/otelrudder
+
+go 1.20
+
+require (
+	github.com/rudderlabs/rudder-api-go v0.1.0
+	github.com/stretchr/testify v1.8.4
+	go.opentelemetry.io/otel v1.24.0
+	go.opentelemetry.io/otel/metric v1.24.0
+	go.opentelemetry.io/otel/sdk v1.24.0
+	go.opentelemetry.io/otel/sdk/metric v1.24.0
+	go.opentelemetry.io/otel/trace v1.24.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// Local development only: replace directives are ignored by consumers, who get the version required above.
+// See "Releasing" in CONTRIBUTING.md for the tagging order.
+replace github.com/rudderlabs/rudder-api-go => ../
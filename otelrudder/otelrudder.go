@@ -0,0 +1,186 @@
+// Package otelrudder instruments the Rudder API client with OpenTelemetry tracing and metrics.
+//
+// It lives in its own module, so that the client does not depend on OpenTelemetry.
+package otelrudder
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/rudderlabs/rudder-api-go/otelrudder"
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider used to create spans. Defaults to the global one.
+func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tracerProvider
+	}
+}
+
+// WithMeterProvider sets the meter provider used to create metrics. Defaults to the global one.
+func WithMeterProvider(meterProvider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = meterProvider
+	}
+}
+
+// WithPropagators sets the propagators used to inject the trace context in request headers.
+// Defaults to the global ones.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = propagators
+	}
+}
+
+// WithInstrumentation returns a client option which instruments every call of Client.Do:
+//   - a client span is created per call, named after the operation (e.g. "sources.Create"), with the HTTP
+//     status code and API error code as attributes, and an event per attempt;
+//   - the trace context is propagated through the request headers;
+//   - the rudder.client.requests counter and the rudder.client.request.duration histogram record the calls.
+func WithInstrumentation(options ...Option) client.Option {
+	cfg := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, o := range options {
+		o(cfg)
+	}
+
+	h, err := newHook(cfg)
+	if err != nil {
+		return func(*client.Client) error { return err }
+	}
+
+	return client.WithHook(h)
+}
+
+type hook struct {
+	tracer      trace.Tracer
+	propagators propagation.TextMapPropagator
+	requests    metric.Int64Counter
+	duration    metric.Float64Histogram
+}
+
+func newHook(cfg *config) (*hook, error) {
+	meter := cfg.meterProvider.Meter(instrumentationName)
+
+	requests, err := meter.Int64Counter("rudder.client.requests",
+		metric.WithDescription("Number of calls made to the Rudder API"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram("rudder.client.request.duration",
+		metric.WithDescription("Duration of the calls made to the Rudder API, including retries"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &hook{
+		tracer:      cfg.tracerProvider.Tracer(instrumentationName),
+		propagators: cfg.propagators,
+		requests:    requests,
+		duration:    duration,
+	}, nil
+}
+
+func spanName(call *client.CallInfo) string {
+	if call.Operation != "" {
+		return call.Operation
+	}
+	return "rudder-api " + call.Method
+}
+
+func (h *hook) StartCall(ctx context.Context, call *client.CallInfo) context.Context {
+	ctx, _ = h.tracer.Start(ctx, spanName(call),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("rudder.operation", call.Operation),
+			attribute.String("http.request.method", call.Method),
+		))
+	return ctx
+}
+
+func (h *hook) BeforeRequest(ctx context.Context, req *client.RequestInfo) {
+	h.propagators.Inject(ctx, headerCarrier{req})
+}
+
+func (h *hook) AfterResponse(ctx context.Context, res *client.ResponseInfo) {
+	attributes := []attribute.KeyValue{attribute.Int("rudder.attempt", res.Request.Attempt)}
+	if res.StatusCode != 0 {
+		attributes = append(attributes, attribute.Int("http.response.status_code", res.StatusCode))
+	}
+	if res.ErrorCode != "" {
+		attributes = append(attributes, attribute.String("rudder.error_code", res.ErrorCode))
+	}
+
+	trace.SpanFromContext(ctx).AddEvent("attempt", trace.WithAttributes(attributes...))
+}
+
+func (h *hook) EndCall(ctx context.Context, call *client.CallInfo, duration time.Duration, err error) {
+	attributes := []attribute.KeyValue{
+		attribute.String("rudder.operation", call.Operation),
+		attribute.String("http.request.method", call.Method),
+	}
+	if call.StatusCode != 0 {
+		attributes = append(attributes, attribute.Int("http.response.status_code", call.StatusCode))
+	}
+
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode != "" {
+		attributes = append(attributes, attribute.String("rudder.error_code", apiErr.ErrorCode))
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attributes...)
+	span.SetAttributes(attribute.Int("rudder.attempts", call.Attempts))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+
+	h.requests.Add(ctx, 1, metric.WithAttributes(attributes...))
+	h.duration.Record(ctx, duration.Seconds(), metric.WithAttributes(attributes...))
+}
+
+// headerCarrier injects propagation headers in the requests sent by the client.
+type headerCarrier struct {
+	req *client.RequestInfo
+}
+
+func (c headerCarrier) Get(key string) string {
+	return c.req.Header.Get(key)
+}
+
+func (c headerCarrier) Set(key, value string) {
+	c.req.SetHeader(key, value)
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.req.Header))
+	for key := range c.req.Header {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,89 @@
+package otelrudder_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/otelrudder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInstrumentation(t *testing.T) {
+	var traceparents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		traceparents = append(traceparents, req.Header.Get("Traceparent"))
+		if req.Method == "DELETE" {
+			w.WriteHeader(404)
+			w.Write([]byte(`{"error": "not found", "code": "not_found"}`))
+			return
+		}
+		w.Write([]byte(`{"source": {"id": "some-id"}}`))
+	}))
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	c, err := client.New("some-access-token",
+		client.WithBaseURL(server.URL),
+		otelrudder.WithInstrumentation(
+			otelrudder.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+			otelrudder.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+			otelrudder.WithPropagators(propagation.TraceContext{}),
+		))
+	require.NoError(t, err)
+
+	_, err = c.Sources.Get(context.Background(), "some-id")
+	require.NoError(t, err)
+
+	err = c.Sources.Delete(context.Background(), "some-id")
+	require.Error(t, err)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+
+	assert.Equal(t, "sources.Get", ended[0].Name())
+	assert.Contains(t, ended[0].Attributes(), attribute.Int("http.response.status_code", 200))
+	assert.Contains(t, ended[0].Attributes(), attribute.Int("rudder.attempts", 1))
+	assert.Equal(t, codes.Unset, ended[0].Status().Code)
+	require.Len(t, ended[0].Events(), 1)
+	assert.Equal(t, "attempt", ended[0].Events()[0].Name)
+
+	assert.Equal(t, "sources.Delete", ended[1].Name())
+	assert.Contains(t, ended[1].Attributes(), attribute.Int("http.response.status_code", 404))
+	assert.Contains(t, ended[1].Attributes(), attribute.String("rudder.error_code", "not_found"))
+	assert.Equal(t, codes.Error, ended[1].Status().Code)
+
+	// the trace context of each span is propagated to the API
+	require.Len(t, traceparents, 2)
+	assert.Contains(t, traceparents[0], ended[0].SpanContext().TraceID().String())
+	assert.Contains(t, traceparents[1], ended[1].SpanContext().TraceID().String())
+
+	var metrics metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &metrics))
+	require.Len(t, metrics.ScopeMetrics, 1)
+
+	names := map[string]metricdata.Aggregation{}
+	for _, m := range metrics.ScopeMetrics[0].Metrics {
+		names[m.Name] = m.Data
+	}
+
+	requests, ok := names["rudder.client.requests"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	assert.Len(t, requests.DataPoints, 2)
+
+	duration, ok := names["rudder.client.request.duration"].(metricdata.Histogram[float64])
+	require.True(t, ok)
+	assert.Len(t, duration.DataPoints, 2)
+}
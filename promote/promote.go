@@ -0,0 +1,224 @@
+// Package promote copies sources, with their connected destinations and connections, from a workspace to another,
+// e.g. from staging to production. Resources are matched by name in the target workspace, where they are created
+// or updated; resources of the target workspace which are not promoted are left untouched.
+package promote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/jsonmerge"
+	"github.com/rudderlabs/rudder-api-go/reconcile"
+	"gopkg.in/yaml.v3"
+)
+
+// Overrides are JSON merge patches applied to the configs of the promoted sources and destinations, by name, e.g.
+// {"warehouse": {"host": "db.prod.example.com"}} to promote a destination named "warehouse" with a different host.
+type Overrides map[string]json.RawMessage
+
+// LoadOverrides reads overrides in YAML or JSON format, mapping names to config patches.
+func LoadOverrides(r io.Reader) (Overrides, error) {
+	var document map[string]interface{}
+	if err := yaml.NewDecoder(r).Decode(&document); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("could not parse overrides: %w", err)
+	}
+
+	overrides := Overrides{}
+	for name, patch := range document {
+		data, err := json.Marshal(patch)
+		if err != nil {
+			return nil, fmt.Errorf("invalid overrides for '%s': %w", name, err)
+		}
+		overrides[name] = data
+	}
+	return overrides, nil
+}
+
+type promotion struct {
+	overrides Overrides
+	dryRun    bool
+}
+
+type Option func(*promotion)
+
+// WithOverrides applies per-environment overrides to the configs of the promoted sources and destinations.
+func WithOverrides(overrides Overrides) Option {
+	return func(p *promotion) {
+		p.overrides = overrides
+	}
+}
+
+// WithDryRun makes Promote print the diff without applying it.
+func WithDryRun() Option {
+	return func(p *promotion) {
+		p.dryRun = true
+	}
+}
+
+// NewPlan returns the changes needed to promote the sources with the given names from a workspace to another,
+// along with the destinations they are connected to and their connections.
+func NewPlan(ctx context.Context, from, to *client.Client, sources []string, options ...Option) (*reconcile.Plan, error) {
+	p := &promotion{}
+	for _, o := range options {
+		o(p)
+	}
+
+	spec, err := p.spec(ctx, from, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	return reconcile.NewPlan(ctx, to, spec, reconcile.WithoutDeletes())
+}
+
+// Promote prints the diff of the promotion to w, and then applies it, unless WithDryRun is given.
+func Promote(ctx context.Context, from, to *client.Client, sources []string, w io.Writer, options ...Option) (*reconcile.Plan, error) {
+	p := &promotion{}
+	for _, o := range options {
+		o(p)
+	}
+
+	plan, err := NewPlan(ctx, from, to, sources, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.WriteString(w, plan.Diff()); err != nil {
+		return nil, err
+	}
+
+	if p.dryRun {
+		return plan, nil
+	}
+
+	return plan, reconcile.Apply(ctx, to, plan)
+}
+
+// spec describes the selected sources of the workspace, with their destinations and connections.
+func (p *promotion) spec(ctx context.Context, c *client.Client, names []string) (*reconcile.Spec, error) {
+	selected := map[string]bool{}
+	for _, name := range names {
+		selected[name] = true
+	}
+
+	spec := &reconcile.Spec{}
+	sourceNames := map[string]string{}
+	sources := c.Sources.All(ctx)
+	for sources.Next() {
+		source := sources.Source()
+		if !selected[source.Name] {
+			continue
+		}
+		if contains(sourceNames, source.Name) {
+			return nil, fmt.Errorf("multiple sources are named '%s'", source.Name)
+		}
+		sourceNames[source.ID] = source.Name
+
+		config, err := p.config(source.Name, source.Config)
+		if err != nil {
+			return nil, err
+		}
+		enabled := source.IsEnabled
+		spec.Sources = append(spec.Sources, reconcile.SourceSpec{Name: source.Name, Type: source.Type, Enabled: &enabled, Config: config})
+	}
+	if err := sources.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		if !contains(sourceNames, name) {
+			return nil, fmt.Errorf("source '%s' not found", name)
+		}
+	}
+
+	var connections []client.Connection
+	connected := map[string]bool{}
+	connectionsIt := c.Connections.All(ctx)
+	for connectionsIt.Next() {
+		connection := connectionsIt.Connection()
+		if _, ok := sourceNames[connection.SourceID]; ok {
+			connections = append(connections, connection)
+			connected[connection.DestinationID] = true
+		}
+	}
+	if err := connectionsIt.Err(); err != nil {
+		return nil, err
+	}
+
+	destinationNames := map[string]string{}
+	destinations := c.Destinations.All(ctx)
+	for destinations.Next() {
+		destination := destinations.Destination()
+		if !connected[destination.ID] {
+			continue
+		}
+		if contains(destinationNames, destination.Name) {
+			return nil, fmt.Errorf("multiple destinations are named '%s'", destination.Name)
+		}
+		destinationNames[destination.ID] = destination.Name
+
+		config, err := p.config(destination.Name, destination.Config)
+		if err != nil {
+			return nil, err
+		}
+		enabled := destination.IsEnabled
+		spec.Destinations = append(spec.Destinations, reconcile.DestinationSpec{Name: destination.Name, Type: destination.Type, Enabled: &enabled, Config: config})
+	}
+	if err := destinations.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, connection := range connections {
+		enabled := connection.IsEnabled
+		spec.Connections = append(spec.Connections, reconcile.ConnectionSpec{
+			Source:      sourceNames[connection.SourceID],
+			Destination: destinationNames[connection.DestinationID],
+			Enabled:     &enabled,
+		})
+	}
+
+	var unused []string
+	for name := range p.overrides {
+		if !contains(sourceNames, name) && !contains(destinationNames, name) {
+			unused = append(unused, name)
+		}
+	}
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		return nil, fmt.Errorf("overrides do not match any promoted source or destination: %v", unused)
+	}
+
+	return spec, nil
+}
+
+// config returns the config of a resource with its overrides applied, in the format of specs.
+func (p *promotion) config(name string, config json.RawMessage) (map[string]interface{}, error) {
+	if patch, ok := p.overrides[name]; ok {
+		patched, err := jsonmerge.Patch(config, patch)
+		if err != nil {
+			return nil, fmt.Errorf("could not apply overrides of '%s': %w", name, err)
+		}
+		config = patched
+	}
+
+	fields := map[string]interface{}{}
+	if len(config) > 0 && string(config) != "null" {
+		if err := json.Unmarshal(config, &fields); err != nil {
+			return nil, fmt.Errorf("invalid config of '%s': %w", name, err)
+		}
+	}
+	return fields, nil
+}
+
+func contains(names map[string]string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
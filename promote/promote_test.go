@@ -0,0 +1,136 @@
+package promote_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/promote"
+	"github.com/rudderlabs/rudder-api-go/rudderapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWorkspace(t *testing.T) *client.Client {
+	server := rudderapitest.NewServer()
+	t.Cleanup(server.Close)
+
+	c, err := server.Client()
+	require.NoError(t, err)
+	return c
+}
+
+func seedStaging(t *testing.T, c *client.Client) {
+	ctx := context.Background()
+
+	web, err := c.Sources.Create(ctx, &client.Source{Name: "web", Type: "Javascript", IsEnabled: true, Config: json.RawMessage(`{}`)})
+	require.NoError(t, err)
+	legacy, err := c.Sources.Create(ctx, &client.Source{Name: "legacy", Type: "HTTP", IsEnabled: true, Config: json.RawMessage(`{}`)})
+	require.NoError(t, err)
+
+	warehouse, err := c.Destinations.Create(ctx, &client.Destination{
+		Name:      "warehouse",
+		Type:      "POSTGRES",
+		IsEnabled: true,
+		Config:    json.RawMessage(`{"host": "db.staging.example.com", "database": "events"}`),
+	})
+	require.NoError(t, err)
+	webhook, err := c.Destinations.Create(ctx, &client.Destination{Name: "webhook", Type: "WEBHOOK", IsEnabled: true, Config: json.RawMessage(`{}`)})
+	require.NoError(t, err)
+
+	_, err = c.Connections.Create(ctx, &client.Connection{SourceID: web.ID, DestinationID: warehouse.ID, IsEnabled: true})
+	require.NoError(t, err)
+	_, err = c.Connections.Create(ctx, &client.Connection{SourceID: legacy.ID, DestinationID: webhook.ID, IsEnabled: true})
+	require.NoError(t, err)
+}
+
+func TestPromote(t *testing.T) {
+	ctx := context.Background()
+
+	staging, production := newWorkspace(t), newWorkspace(t)
+	seedStaging(t, staging)
+
+	overrides, err := promote.LoadOverrides(strings.NewReader(`
+warehouse:
+  host: db.prod.example.com
+`))
+	require.NoError(t, err)
+
+	// a dry run only prints the diff
+	var diff bytes.Buffer
+	_, err = promote.Promote(ctx, staging, production, []string{"web"}, &diff, promote.WithOverrides(overrides), promote.WithDryRun())
+	require.NoError(t, err)
+	assert.Equal(t, `+ source web
+    name: "web"
+    type: "Javascript"
+    enabled: true
++ destination warehouse
+    name: "warehouse"
+    type: "POSTGRES"
+    enabled: true
+    config.database: "events"
+    config.host: "db.prod.example.com"
++ connection web -> warehouse
+    enabled: true
+`, diff.String())
+
+	sources, err := production.Sources.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, sources.Sources)
+
+	diff.Reset()
+	_, err = promote.Promote(ctx, staging, production, []string{"web"}, &diff, promote.WithOverrides(overrides))
+	require.NoError(t, err)
+
+	destinations, err := production.Destinations.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, destinations.Destinations, 1)
+	assert.Equal(t, "warehouse", destinations.Destinations[0].Name)
+	assert.JSONEq(t, `{"host": "db.prod.example.com", "database": "events"}`, string(destinations.Destinations[0].Config))
+
+	connections, err := production.Connections.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, connections.Connections, 1)
+
+	// promoting again changes nothing
+	plan, err := promote.NewPlan(ctx, staging, production, []string{"web"}, promote.WithOverrides(overrides))
+	require.NoError(t, err)
+	assert.True(t, plan.Empty())
+}
+
+func TestPromoteKeepsOtherResources(t *testing.T) {
+	ctx := context.Background()
+
+	staging, production := newWorkspace(t), newWorkspace(t)
+	seedStaging(t, staging)
+
+	_, err := production.Sources.Create(ctx, &client.Source{Name: "mobile", Type: "Android", IsEnabled: true, Config: json.RawMessage(`{}`)})
+	require.NoError(t, err)
+
+	var diff bytes.Buffer
+	_, err = promote.Promote(ctx, staging, production, []string{"legacy"}, &diff)
+	require.NoError(t, err)
+	assert.NotContains(t, diff.String(), "mobile")
+
+	sources, err := production.Sources.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, sources.Sources, 2)
+}
+
+func TestPromoteInvalidSelection(t *testing.T) {
+	ctx := context.Background()
+
+	staging, production := newWorkspace(t), newWorkspace(t)
+	seedStaging(t, staging)
+
+	_, err := promote.NewPlan(ctx, staging, production, []string{"unknown"})
+	assert.EqualError(t, err, "source 'unknown' not found")
+
+	_, err = promote.NewPlan(ctx, staging, production, []string{"web"}, promote.WithOverrides(promote.Overrides{
+		"webhook": json.RawMessage(`{"webhookUrl": "https://example.com"}`),
+	}))
+	assert.EqualError(t, err, "overrides do not match any promoted source or destination: [webhook]")
+}
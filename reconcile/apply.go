@@ -0,0 +1,101 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+)
+
+// ApplyError is returned by Apply when a change fails. Changes preceding it in the plan have been applied.
+type ApplyError struct {
+	Change Change
+	Err    error
+}
+
+func (e *ApplyError) Error() string {
+	return fmt.Sprintf("could not %s %s '%s': %v", e.Change.Action, e.Change.Kind, e.Change.Key, e.Err)
+}
+
+func (e *ApplyError) Unwrap() error {
+	return e.Err
+}
+
+// Apply applies the changes of a plan in order, stopping at the first error.
+func Apply(ctx context.Context, c *client.Client, plan *Plan) error {
+	sourceIDs := map[string]string{}
+	for key, id := range plan.sourceIDs {
+		sourceIDs[key] = id
+	}
+	destinationIDs := map[string]string{}
+	for key, id := range plan.destinationIDs {
+		destinationIDs[key] = id
+	}
+
+	for _, change := range plan.Changes {
+		if err := apply(ctx, c, change, sourceIDs, destinationIDs); err != nil {
+			return &ApplyError{Change: change, Err: err}
+		}
+	}
+
+	return nil
+}
+
+func apply(ctx context.Context, c *client.Client, change Change, sourceIDs, destinationIDs map[string]string) error {
+	switch change.Kind {
+	case SourceKind:
+		switch change.Action {
+		case Create:
+			source, err := c.Sources.Create(ctx, change.Source)
+			if err != nil {
+				return err
+			}
+			sourceIDs[change.Key] = source.ID
+			return nil
+		case Update:
+			_, err := c.Sources.Update(ctx, change.Source)
+			return err
+		case Delete:
+			return c.Sources.Delete(ctx, change.ID)
+		}
+
+	case DestinationKind:
+		switch change.Action {
+		case Create:
+			destination, err := c.Destinations.Create(ctx, change.Destination)
+			if err != nil {
+				return err
+			}
+			destinationIDs[change.Key] = destination.ID
+			return nil
+		case Update:
+			_, err := c.Destinations.Update(ctx, change.Destination)
+			return err
+		case Delete:
+			return c.Destinations.Delete(ctx, change.ID)
+		}
+
+	case ConnectionKind:
+		if change.Action == Delete {
+			return c.Connections.Delete(ctx, change.ID)
+		}
+
+		connection := *change.Connection
+		connection.SourceID = sourceIDs[change.SourceKey]
+		connection.DestinationID = destinationIDs[change.DestinationKey]
+		if connection.SourceID == "" || connection.DestinationID == "" {
+			return fmt.Errorf("could not resolve source '%s' or destination '%s'", change.SourceKey, change.DestinationKey)
+		}
+
+		switch change.Action {
+		case Create:
+			_, err := c.Connections.Create(ctx, &connection)
+			return err
+		case Update:
+			_, err := c.Connections.Update(ctx, &connection)
+			return err
+		}
+	}
+
+	return fmt.Errorf("unsupported change")
+}
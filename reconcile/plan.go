@@ -0,0 +1,482 @@
+// Package reconcile reconciles a workspace with a declarative spec of its sources, destinations and connections.
+package reconcile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/redact"
+)
+
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+type Kind string
+
+const (
+	SourceKind      Kind = "source"
+	DestinationKind Kind = "destination"
+	ConnectionKind  Kind = "connection"
+)
+
+// Change is a single operation of a plan. Exactly one of Source, Destination or Connection is set, depending on Kind:
+// it holds the desired state for creates and updates, and the live state for deletes.
+type Change struct {
+	Action Action
+	Kind   Kind
+	// Key is the key of the resource; for connections, it is made of the keys of their source and destination.
+	Key string
+	// ID is the ID of the live resource, empty for creates.
+	ID string
+
+	Source      *client.Source
+	Destination *client.Destination
+	Connection  *client.Connection
+
+	// SourceKey and DestinationKey are the keys of the resources a connection links, for connection changes.
+	// They are resolved to IDs when the plan is applied, as the resources might not exist yet.
+	SourceKey      string
+	DestinationKey string
+
+	// Diff lists the fields set by creates and changed by updates. It is empty for deletes.
+	Diff []FieldDiff
+}
+
+// FieldDiff is the change of a field of a resource, e.g. "name" or "config.host". Old and New are JSON encoded
+// values, Old being empty for creates and for config fields which are not set yet. The values of secret config
+// fields, at any depth, are redacted.
+type FieldDiff struct {
+	Field string
+	Old   string
+	New   string
+}
+
+func (d FieldDiff) String() string {
+	if d.Old == "" {
+		return fmt.Sprintf("%s: %s", d.Field, d.New)
+	}
+	return fmt.Sprintf("%s: %s -> %s", d.Field, d.Old, d.New)
+}
+
+func (c Change) String() string {
+	symbol := map[Action]string{Create: "+", Update: "~", Delete: "-"}[c.Action]
+	return fmt.Sprintf("%s %s %s", symbol, c.Kind, c.Key)
+}
+
+// Plan lists the changes needed to reconcile a workspace with a spec, in the order they have to be applied:
+// sources, destinations and then connections are created or updated, before connections, destinations
+// and then sources are deleted.
+type Plan struct {
+	Changes []Change
+
+	// IDs of live sources and destinations, by key
+	sourceIDs      map[string]string
+	destinationIDs map[string]string
+}
+
+// Empty reports whether the workspace is already reconciled with the spec.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "no changes\n"
+	}
+
+	var b bytes.Buffer
+	for _, change := range p.Changes {
+		fmt.Fprintln(&b, change)
+	}
+	return b.String()
+}
+
+// Diff returns the changes of the plan along with their field level differences, e.g. for a review before the
+// plan is applied.
+func (p *Plan) Diff() string {
+	if p.Empty() {
+		return "no changes\n"
+	}
+
+	var b bytes.Buffer
+	for _, change := range p.Changes {
+		fmt.Fprintln(&b, change)
+		for _, diff := range change.Diff {
+			fmt.Fprintf(&b, "    %s\n", diff)
+		}
+	}
+	return b.String()
+}
+
+type Option func(*planner)
+
+// WithSourceKey sets the function returning the key of a live source, which is matched against the keys
+// of the spec. By default, live sources are matched with the spec sources of the same name, spec keys only being
+// used to reference sources in connections.
+func WithSourceKey(key func(client.Source) string) Option {
+	return func(p *planner) {
+		p.sourceKey = key
+	}
+}
+
+// WithDestinationKey sets the function returning the key of a live destination, which is matched against the keys
+// of the spec. By default, live destinations are matched with the spec destinations of the same name, spec keys only
+// being used to reference destinations in connections.
+func WithDestinationKey(key func(client.Destination) string) Option {
+	return func(p *planner) {
+		p.destinationKey = key
+	}
+}
+
+// WithoutDeletes keeps the live resources which are not part of the spec, instead of deleting them. It suits specs
+// which only describe a part of the workspace.
+func WithoutDeletes() Option {
+	return func(p *planner) {
+		p.withoutDeletes = true
+	}
+}
+
+type planner struct {
+	sourceKey      func(client.Source) string
+	destinationKey func(client.Destination) string
+	withoutDeletes bool
+}
+
+// NewPlan compares the live workspace with the spec and returns the changes needed to reconcile them.
+// Live resources which are not part of the spec are deleted.
+func NewPlan(ctx context.Context, c *client.Client, spec *Spec, options ...Option) (*Plan, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &planner{}
+	for _, o := range options {
+		o(p)
+	}
+
+	var err error
+	if p.sourceKey == nil {
+		if p.sourceKey, err = sourceKeysByName(spec); err != nil {
+			return nil, err
+		}
+	}
+	if p.destinationKey == nil {
+		if p.destinationKey, err = destinationKeysByName(spec); err != nil {
+			return nil, err
+		}
+	}
+
+	var sources []client.Source
+	sourcesIt := c.Sources.All(ctx)
+	for sourcesIt.Next() {
+		sources = append(sources, sourcesIt.Source())
+	}
+	if err := sourcesIt.Err(); err != nil {
+		return nil, err
+	}
+
+	var destinations []client.Destination
+	destinationsIt := c.Destinations.All(ctx)
+	for destinationsIt.Next() {
+		destinations = append(destinations, destinationsIt.Destination())
+	}
+	if err := destinationsIt.Err(); err != nil {
+		return nil, err
+	}
+
+	var connections []client.Connection
+	connectionsIt := c.Connections.All(ctx)
+	for connectionsIt.Next() {
+		connections = append(connections, connectionsIt.Connection())
+	}
+	if err := connectionsIt.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.plan(spec, sources, destinations, connections)
+}
+
+// sourceKeysByName returns the default key function of live sources, which gives live sources the key of the spec
+// source of the same name, or their name if there is none.
+func sourceKeysByName(spec *Spec) (func(client.Source) string, error) {
+	keys := map[string]string{}
+	for _, s := range spec.Sources {
+		if _, ok := keys[s.Name]; ok {
+			return nil, fmt.Errorf("multiple sources named '%s' in spec, use WithSourceKey to match them", s.Name)
+		}
+		keys[s.Name] = s.key()
+	}
+
+	return func(s client.Source) string {
+		if key, ok := keys[s.Name]; ok {
+			return key
+		}
+		return s.Name
+	}, nil
+}
+
+// destinationKeysByName is like sourceKeysByName, for destinations.
+func destinationKeysByName(spec *Spec) (func(client.Destination) string, error) {
+	keys := map[string]string{}
+	for _, d := range spec.Destinations {
+		if _, ok := keys[d.Name]; ok {
+			return nil, fmt.Errorf("multiple destinations named '%s' in spec, use WithDestinationKey to match them", d.Name)
+		}
+		keys[d.Name] = d.key()
+	}
+
+	return func(d client.Destination) string {
+		if key, ok := keys[d.Name]; ok {
+			return key
+		}
+		return d.Name
+	}, nil
+}
+
+func (p *planner) plan(spec *Spec, sources []client.Source, destinations []client.Destination, connections []client.Connection) (*Plan, error) {
+	plan := &Plan{
+		sourceIDs:      map[string]string{},
+		destinationIDs: map[string]string{},
+	}
+	var deletes []Change
+
+	liveSources := map[string]client.Source{}
+	sourceKeys := map[string]string{}
+	for _, source := range sources {
+		key := p.sourceKey(source)
+		if _, ok := liveSources[key]; ok {
+			return nil, fmt.Errorf("multiple live sources match key '%s'", key)
+		}
+		liveSources[key] = source
+		sourceKeys[source.ID] = key
+		plan.sourceIDs[key] = source.ID
+	}
+
+	liveDestinations := map[string]client.Destination{}
+	destinationKeys := map[string]string{}
+	for _, destination := range destinations {
+		key := p.destinationKey(destination)
+		if _, ok := liveDestinations[key]; ok {
+			return nil, fmt.Errorf("multiple live destinations match key '%s'", key)
+		}
+		liveDestinations[key] = destination
+		destinationKeys[destination.ID] = key
+		plan.destinationIDs[key] = destination.ID
+	}
+
+	liveConnections := map[string]client.Connection{}
+	for _, connection := range connections {
+		liveConnections[connectionKey(sourceKeys[connection.SourceID], destinationKeys[connection.DestinationID])] = connection
+	}
+
+	desiredSources := map[string]bool{}
+	for _, s := range spec.Sources {
+		key := s.key()
+		desiredSources[key] = true
+
+		config, err := marshalConfig(s.Config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config for source '%s': %w", key, err)
+		}
+		desired := &client.Source{Name: s.Name, Type: s.Type, IsEnabled: enabled(s.Enabled), Config: config}
+
+		live, ok := liveSources[key]
+		if !ok {
+			_, configDiff, err := mergeConfig(nil, config)
+			if err != nil {
+				return nil, fmt.Errorf("invalid config for source '%s': %w", key, err)
+			}
+			diff := resourceDiff(nil, []interface{}{desired.Name, desired.Type, desired.IsEnabled}, configDiff)
+			plan.Changes = append(plan.Changes, Change{Action: Create, Kind: SourceKind, Key: key, Source: desired, Diff: diff})
+			continue
+		}
+
+		merged, configDiff, err := mergeConfig(live.Config, config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid live config for source '%s': %w", key, err)
+		}
+		diff := resourceDiff([]interface{}{live.Name, live.Type, live.IsEnabled}, []interface{}{desired.Name, desired.Type, desired.IsEnabled}, configDiff)
+		if len(diff) > 0 {
+			desired.ID = live.ID
+			desired.Config = merged
+			plan.Changes = append(plan.Changes, Change{Action: Update, Kind: SourceKind, Key: key, ID: live.ID, Source: desired, Diff: diff})
+		}
+	}
+
+	desiredDestinations := map[string]bool{}
+	for _, d := range spec.Destinations {
+		key := d.key()
+		desiredDestinations[key] = true
+
+		config, err := marshalConfig(d.Config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config for destination '%s': %w", key, err)
+		}
+		desired := &client.Destination{Name: d.Name, Type: d.Type, IsEnabled: enabled(d.Enabled), Config: config}
+
+		live, ok := liveDestinations[key]
+		if !ok {
+			_, configDiff, err := mergeConfig(nil, config)
+			if err != nil {
+				return nil, fmt.Errorf("invalid config for destination '%s': %w", key, err)
+			}
+			diff := resourceDiff(nil, []interface{}{desired.Name, desired.Type, desired.IsEnabled}, configDiff)
+			plan.Changes = append(plan.Changes, Change{Action: Create, Kind: DestinationKind, Key: key, Destination: desired, Diff: diff})
+			continue
+		}
+
+		merged, configDiff, err := mergeConfig(live.Config, config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid live config for destination '%s': %w", key, err)
+		}
+		diff := resourceDiff([]interface{}{live.Name, live.Type, live.IsEnabled}, []interface{}{desired.Name, desired.Type, desired.IsEnabled}, configDiff)
+		if len(diff) > 0 {
+			desired.ID = live.ID
+			desired.Config = merged
+			plan.Changes = append(plan.Changes, Change{Action: Update, Kind: DestinationKind, Key: key, ID: live.ID, Destination: desired, Diff: diff})
+		}
+	}
+
+	desiredConnections := map[string]bool{}
+	for _, c := range spec.Connections {
+		key := connectionKey(c.Source, c.Destination)
+		desiredConnections[key] = true
+		desired := &client.Connection{IsEnabled: enabled(c.Enabled)}
+
+		live, ok := liveConnections[key]
+		if !ok {
+			diff := []FieldDiff{{Field: "enabled", New: encode(desired.IsEnabled)}}
+			plan.Changes = append(plan.Changes, Change{Action: Create, Kind: ConnectionKind, Key: key, Connection: desired, SourceKey: c.Source, DestinationKey: c.Destination, Diff: diff})
+			continue
+		}
+
+		if live.IsEnabled != desired.IsEnabled {
+			desired.ID = live.ID
+			diff := []FieldDiff{{Field: "enabled", Old: encode(live.IsEnabled), New: encode(desired.IsEnabled)}}
+			plan.Changes = append(plan.Changes, Change{Action: Update, Kind: ConnectionKind, Key: key, ID: live.ID, Connection: desired, SourceKey: c.Source, DestinationKey: c.Destination, Diff: diff})
+		}
+	}
+
+	if p.withoutDeletes {
+		return plan, nil
+	}
+
+	// deletes are applied in reverse dependency order: connections, destinations and then sources
+	for i := range connections {
+		connection := connections[i]
+		sourceKey, destinationKey := sourceKeys[connection.SourceID], destinationKeys[connection.DestinationID]
+		key := connectionKey(sourceKey, destinationKey)
+		if !desiredConnections[key] {
+			deletes = append(deletes, Change{Action: Delete, Kind: ConnectionKind, Key: key, ID: connection.ID, Connection: &connection, SourceKey: sourceKey, DestinationKey: destinationKey})
+		}
+	}
+
+	for i := range destinations {
+		destination := destinations[i]
+		key := destinationKeys[destination.ID]
+		if !desiredDestinations[key] {
+			deletes = append(deletes, Change{Action: Delete, Kind: DestinationKind, Key: key, ID: destination.ID, Destination: &destination})
+		}
+	}
+
+	for i := range sources {
+		source := sources[i]
+		key := sourceKeys[source.ID]
+		if !desiredSources[key] {
+			deletes = append(deletes, Change{Action: Delete, Kind: SourceKind, Key: key, ID: source.ID, Source: &source})
+		}
+	}
+
+	plan.Changes = append(plan.Changes, deletes...)
+	return plan, nil
+}
+
+// mergeConfig sets the top level fields of the desired configuration on the live one, returning the fields which
+// differ. Live fields which are not part of the desired configuration, such as defaults set by the API, are left
+// untouched.
+func mergeConfig(live, desired json.RawMessage) (json.RawMessage, []FieldDiff, error) {
+	liveFields := map[string]interface{}{}
+	if len(live) > 0 && string(live) != "null" {
+		if err := json.Unmarshal(live, &liveFields); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	desiredFields := map[string]interface{}{}
+	if err := json.Unmarshal(desired, &desiredFields); err != nil {
+		return nil, nil, err
+	}
+
+	keys := make([]string, 0, len(desiredFields))
+	for key := range desiredFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var diff []FieldDiff
+	for _, key := range keys {
+		value := desiredFields[key]
+		current, ok := liveFields[key]
+		if ok && reflect.DeepEqual(current, value) {
+			continue
+		}
+
+		fieldDiff := FieldDiff{Field: "config." + key, New: encodeRedacted(key, value)}
+		if ok {
+			fieldDiff.Old = encodeRedacted(key, current)
+		}
+		diff = append(diff, fieldDiff)
+		liveFields[key] = value
+	}
+
+	if len(diff) == 0 {
+		return live, nil, nil
+	}
+
+	merged, err := json.Marshal(liveFields)
+	return merged, diff, err
+}
+
+// resourceFields are the fields compared by resourceDiff, besides the configuration.
+var resourceFields = []string{"name", "type", "enabled"}
+
+// resourceDiff returns the differences between the live and desired values of resourceFields, followed by the
+// differences of the configuration. Live values are nil for creates.
+func resourceDiff(live, desired []interface{}, configDiff []FieldDiff) []FieldDiff {
+	var diff []FieldDiff
+	for i, field := range resourceFields {
+		if live == nil {
+			diff = append(diff, FieldDiff{Field: field, New: encode(desired[i])})
+		} else if live[i] != desired[i] {
+			diff = append(diff, FieldDiff{Field: field, Old: encode(live[i]), New: encode(desired[i])})
+		}
+	}
+	return append(diff, configDiff...)
+}
+
+// encodeRedacted encodes the value of a config field, redacting it if the field is secret, or its secret fields, at
+// any depth, otherwise.
+func encodeRedacted(key string, value interface{}) string {
+	if redact.IsSecret(key) {
+		return encode(redact.Placeholder)
+	}
+	return string(redact.JSON([]byte(encode(value))))
+}
+
+func encode(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
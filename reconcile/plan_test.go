@@ -0,0 +1,320 @@
+package reconcile_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/internal/testutils"
+	"github.com/rudderlabs/rudder-api-go/reconcile"
+	"github.com/rudderlabs/rudder-api-go/rudderapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var liveWorkspaceCalls = []testutils.Call{
+	{
+		ResponseStatus: 200,
+		ResponseBody: `{
+			"sources": [
+				{"id": "src-1", "name": "web", "type": "Javascript", "enabled": true, "config": {}},
+				{"id": "src-2", "name": "legacy", "type": "HTTP", "enabled": true, "config": {}}
+			]
+		}`,
+	},
+	{
+		ResponseStatus: 200,
+		ResponseBody: `{
+			"destinations": [
+				{"id": "dst-1", "name": "warehouse", "type": "POSTGRES", "enabled": true, "config": {"host": "old.example.com", "sslMode": "disable"}}
+			]
+		}`,
+	},
+	{
+		ResponseStatus: 200,
+		ResponseBody: `{
+			"connections": [
+				{"id": "conn-1", "sourceId": "src-2", "destinationId": "dst-1", "enabled": true}
+			]
+		}`,
+	},
+}
+
+var desiredSpec = &reconcile.Spec{
+	Sources: []reconcile.SourceSpec{
+		{Name: "web", Type: "Javascript"},
+		{Name: "server", Type: "HTTP"},
+	},
+	Destinations: []reconcile.DestinationSpec{
+		{Name: "warehouse", Type: "POSTGRES", Config: map[string]interface{}{"host": "new.example.com"}},
+	},
+	Connections: []reconcile.ConnectionSpec{
+		{Source: "web", Destination: "warehouse"},
+		{Source: "server", Destination: "warehouse"},
+	},
+}
+
+func TestPlan(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t, liveWorkspaceCalls...)
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	plan, err := reconcile.NewPlan(context.Background(), c, desiredSpec)
+	require.NoError(t, err)
+	assert.Equal(t, `+ source server
+~ destination warehouse
++ connection web -> warehouse
++ connection server -> warehouse
+- connection legacy -> warehouse
+- source legacy
+`, plan.String())
+
+	// live configuration fields which are not part of the spec are kept
+	assert.JSONEq(t, `{"host": "new.example.com", "sslMode": "disable"}`, string(plan.Changes[1].Destination.Config))
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestPlanDiff(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t, liveWorkspaceCalls...)
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	spec := &reconcile.Spec{
+		Sources: []reconcile.SourceSpec{
+			{Name: "server", Type: "HTTP"},
+		},
+		Destinations: []reconcile.DestinationSpec{
+			{Name: "warehouse", Type: "POSTGRES", Config: map[string]interface{}{
+				"host":     "new.example.com",
+				"password": "secret",
+				"auth":     map[string]interface{}{"user": "admin", "password": "hunter2"},
+			}},
+		},
+		Connections: []reconcile.ConnectionSpec{
+			{Source: "server", Destination: "warehouse"},
+		},
+	}
+
+	plan, err := reconcile.NewPlan(context.Background(), c, spec, reconcile.WithoutDeletes())
+	require.NoError(t, err)
+	assert.Equal(t, `+ source server
+    name: "server"
+    type: "HTTP"
+    enabled: true
+~ destination warehouse
+    config.auth: {"password":"[REDACTED]","user":"admin"}
+    config.host: "old.example.com" -> "new.example.com"
+    config.password: "[REDACTED]"
++ connection server -> warehouse
+    enabled: true
+`, plan.Diff())
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestPlanNoChanges(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t, liveWorkspaceCalls...)
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	plan, err := reconcile.NewPlan(context.Background(), c, &reconcile.Spec{
+		Sources: []reconcile.SourceSpec{
+			{Name: "web", Type: "Javascript"},
+			{Name: "legacy", Type: "HTTP"},
+		},
+		Destinations: []reconcile.DestinationSpec{
+			{Name: "warehouse", Type: "POSTGRES", Config: map[string]interface{}{"host": "old.example.com"}},
+		},
+		Connections: []reconcile.ConnectionSpec{
+			{Source: "legacy", Destination: "warehouse"},
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, plan.Empty())
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestPlanWithKey(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t, liveWorkspaceCalls...)
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	plan, err := reconcile.NewPlan(context.Background(), c, &reconcile.Spec{
+		Sources: []reconcile.SourceSpec{
+			{Key: "src-1", Name: "website", Type: "Javascript"},
+			{Key: "src-2", Name: "legacy", Type: "HTTP"},
+		},
+		Destinations: []reconcile.DestinationSpec{
+			{Key: "dst-1", Name: "warehouse", Type: "POSTGRES", Config: map[string]interface{}{"host": "old.example.com"}},
+		},
+		Connections: []reconcile.ConnectionSpec{
+			{Source: "src-2", Destination: "dst-1"},
+		},
+	},
+		reconcile.WithSourceKey(func(s client.Source) string { return s.ID }),
+		reconcile.WithDestinationKey(func(d client.Destination) string { return d.ID }),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "~ source src-1\n", plan.String())
+	assert.Equal(t, "website", plan.Changes[0].Source.Name)
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestApply(t *testing.T) {
+	calls := append([]testutils.Call{}, liveWorkspaceCalls...)
+	calls = append(calls,
+		testutils.Call{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/sources", `{
+					"name": "server", "type": "HTTP", "enabled": true, "config": {}
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{"source": {"id": "src-3", "name": "server", "type": "HTTP", "enabled": true}}`,
+		},
+		testutils.Call{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "PUT", "https://api.rudderstack.com/v2/destinations/dst-1", `{
+					"name": "warehouse", "type": "POSTGRES", "enabled": true, "config": {"host": "new.example.com", "sslMode": "disable"}
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{"destination": {"id": "dst-1"}}`,
+		},
+		testutils.Call{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/connections", `{
+					"sourceId": "src-1", "destinationId": "dst-1", "enabled": true
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{"connection": {"id": "conn-2"}}`,
+		},
+		testutils.Call{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/v2/connections", `{
+					"sourceId": "src-3", "destinationId": "dst-1", "enabled": true
+				}`)
+			},
+			ResponseStatus: 200,
+			ResponseBody:   `{"connection": {"id": "conn-3"}}`,
+		},
+		testutils.Call{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "DELETE", "https://api.rudderstack.com/v2/connections/conn-1", "")
+			},
+			ResponseStatus: 204,
+		},
+		testutils.Call{
+			Validate: func(req *http.Request) bool {
+				return testutils.ValidateRequest(t, req, "DELETE", "https://api.rudderstack.com/v2/sources/src-2", "")
+			},
+			ResponseStatus: 204,
+		},
+	)
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	plan, err := reconcile.NewPlan(context.Background(), c, desiredSpec)
+	require.NoError(t, err)
+	require.NoError(t, reconcile.Apply(context.Background(), c, plan))
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestApplyError(t *testing.T) {
+	calls := append([]testutils.Call{}, liveWorkspaceCalls...)
+	calls = append(calls, testutils.Call{
+		ResponseStatus: 400,
+		ResponseBody:   `{"error": "invalid source", "code": "invalid"}`,
+	})
+
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	plan, err := reconcile.NewPlan(context.Background(), c, desiredSpec)
+	require.NoError(t, err)
+
+	err = reconcile.Apply(context.Background(), c, plan)
+	applyErr, ok := err.(*reconcile.ApplyError)
+	require.True(t, ok)
+	assert.Equal(t, reconcile.Create, applyErr.Change.Action)
+	assert.Equal(t, "server", applyErr.Change.Key)
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestApplyTwiceWithSpecKeys(t *testing.T) {
+	ctx := context.Background()
+	server := rudderapitest.NewServer()
+	defer server.Close()
+
+	c, err := server.Client()
+	require.NoError(t, err)
+
+	spec, err := reconcile.Load(strings.NewReader(`
+sources:
+  - key: web
+    name: My Web
+    type: Javascript
+destinations:
+  - key: pg
+    name: My Postgres
+    type: POSTGRES
+connections:
+  - source: web
+    destination: pg
+`))
+	require.NoError(t, err)
+
+	plan, err := reconcile.NewPlan(ctx, c, spec)
+	require.NoError(t, err)
+	require.NoError(t, reconcile.Apply(ctx, c, plan))
+
+	source, err := c.Sources.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, source.Sources, 1)
+
+	// live resources are matched by name, so the spec keys do not recreate them
+	plan, err = reconcile.NewPlan(ctx, c, spec)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), plan.Diff())
+
+	_, err = reconcile.NewPlan(ctx, c, &reconcile.Spec{Sources: []reconcile.SourceSpec{
+		{Key: "web", Name: "My Web", Type: "Javascript"},
+		{Key: "other", Name: "My Web", Type: "HTTP"},
+	}})
+	assert.EqualError(t, err, "multiple sources named 'My Web' in spec, use WithSourceKey to match them")
+}
+
+func TestApplyDryRun(t *testing.T) {
+	ctx := context.Background()
+	server := rudderapitest.NewServer()
+	defer server.Close()
+
+	recorder := &client.DryRun{}
+	c, err := server.Client(client.WithDryRun(recorder))
+	require.NoError(t, err)
+
+	plan, err := reconcile.NewPlan(ctx, c, &reconcile.Spec{
+		Sources:      []reconcile.SourceSpec{{Name: "web", Type: "Javascript"}},
+		Destinations: []reconcile.DestinationSpec{{Name: "pg", Type: "POSTGRES"}},
+		Connections:  []reconcile.ConnectionSpec{{Source: "web", Destination: "pg"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, reconcile.Apply(ctx, c, plan))
+
+	calls := recorder.Calls()
+	require.Len(t, calls, 3)
+	assert.Equal(t, "sources.Create", calls[0].Operation)
+	assert.Equal(t, "destinations.Create", calls[1].Operation)
+	assert.Equal(t, "connections.Create", calls[2].Operation)
+	assert.JSONEq(t, `{"sourceId": "dryrun-1", "destinationId": "dryrun-2", "enabled": true}`, string(calls[2].Body))
+
+	// nothing was sent
+	sources, err := c.Sources.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, sources.Sources)
+}
@@ -0,0 +1,153 @@
+package reconcile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrEmptySpec is returned when loading an empty document. As live resources missing from a spec are deleted, an
+// empty workspace must be described explicitly, e.g. with "sources: []".
+var ErrEmptySpec = fmt.Errorf("spec is empty")
+
+// Spec describes the desired state of a workspace.
+type Spec struct {
+	Sources      []SourceSpec      `json:"sources" yaml:"sources"`
+	Destinations []DestinationSpec `json:"destinations" yaml:"destinations"`
+	Connections  []ConnectionSpec  `json:"connections" yaml:"connections"`
+}
+
+// SourceSpec describes a source. Key identifies the source within the spec, and is matched against the key of
+// live sources; it defaults to the source name.
+type SourceSpec struct {
+	Key     string                 `json:"key,omitempty" yaml:"key,omitempty"`
+	Name    string                 `json:"name" yaml:"name"`
+	Type    string                 `json:"type" yaml:"type"`
+	Enabled *bool                  `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Config  map[string]interface{} `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// DestinationSpec describes a destination. Key identifies the destination within the spec, and is matched against
+// the key of live destinations; it defaults to the destination name.
+type DestinationSpec struct {
+	Key     string                 `json:"key,omitempty" yaml:"key,omitempty"`
+	Name    string                 `json:"name" yaml:"name"`
+	Type    string                 `json:"type" yaml:"type"`
+	Enabled *bool                  `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Config  map[string]interface{} `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// ConnectionSpec describes a connection between a source and a destination, referenced by their keys.
+type ConnectionSpec struct {
+	Source      string `json:"source" yaml:"source"`
+	Destination string `json:"destination" yaml:"destination"`
+	Enabled     *bool  `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+}
+
+func (s *SourceSpec) key() string {
+	if s.Key != "" {
+		return s.Key
+	}
+	return s.Name
+}
+
+func (s *DestinationSpec) key() string {
+	if s.Key != "" {
+		return s.Key
+	}
+	return s.Name
+}
+
+func connectionKey(sourceKey, destinationKey string) string {
+	return fmt.Sprintf("%s -> %s", sourceKey, destinationKey)
+}
+
+// enabled returns the value of an optional enabled flag, which defaults to true.
+func enabled(flag *bool) bool {
+	return flag == nil || *flag
+}
+
+// Load reads a spec in YAML or JSON format.
+func Load(r io.Reader) (*Spec, error) {
+	spec := &Spec{}
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(spec); err == io.EOF {
+		return nil, ErrEmptySpec
+	} else if err != nil {
+		return nil, fmt.Errorf("could not parse spec: %w", err)
+	}
+	if spec.Sources == nil && spec.Destinations == nil && spec.Connections == nil {
+		return nil, ErrEmptySpec
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+// LoadFile reads a spec in YAML or JSON format from a file.
+func LoadFile(path string) (*Spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Load(f)
+}
+
+// Validate checks that keys are unique and that connections only reference sources and destinations of the spec.
+func (s *Spec) Validate() error {
+	sources := map[string]bool{}
+	for _, source := range s.Sources {
+		if source.key() == "" {
+			return fmt.Errorf("source must have a name or a key")
+		}
+		if sources[source.key()] {
+			return fmt.Errorf("duplicate source key '%s'", source.key())
+		}
+		sources[source.key()] = true
+	}
+
+	destinations := map[string]bool{}
+	for _, destination := range s.Destinations {
+		if destination.key() == "" {
+			return fmt.Errorf("destination must have a name or a key")
+		}
+		if destinations[destination.key()] {
+			return fmt.Errorf("duplicate destination key '%s'", destination.key())
+		}
+		destinations[destination.key()] = true
+	}
+
+	connections := map[string]bool{}
+	for _, connection := range s.Connections {
+		key := connectionKey(connection.Source, connection.Destination)
+		if !sources[connection.Source] {
+			return fmt.Errorf("connection '%s' references unknown source '%s'", key, connection.Source)
+		}
+		if !destinations[connection.Destination] {
+			return fmt.Errorf("connection '%s' references unknown destination '%s'", key, connection.Destination)
+		}
+		if connections[key] {
+			return fmt.Errorf("duplicate connection '%s'", key)
+		}
+		connections[key] = true
+	}
+
+	return nil
+}
+
+// marshalConfig converts a spec configuration to the JSON expected by the API.
+func marshalConfig(config map[string]interface{}) (json.RawMessage, error) {
+	if config == nil {
+		return json.RawMessage("{}"), nil
+	}
+	return json.Marshal(config)
+}
@@ -0,0 +1,101 @@
+package reconcile_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/reconcile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadYAML(t *testing.T) {
+	spec, err := reconcile.Load(strings.NewReader(`
+sources:
+  - name: web
+    type: Javascript
+destinations:
+  - key: warehouse
+    name: My Postgres
+    type: POSTGRES
+    enabled: false
+    config:
+      host: example.com
+      port: "5432"
+connections:
+  - source: web
+    destination: warehouse
+`))
+	require.NoError(t, err)
+
+	disabled := false
+	assert.Equal(t, &reconcile.Spec{
+		Sources: []reconcile.SourceSpec{{Name: "web", Type: "Javascript"}},
+		Destinations: []reconcile.DestinationSpec{{
+			Key:     "warehouse",
+			Name:    "My Postgres",
+			Type:    "POSTGRES",
+			Enabled: &disabled,
+			Config:  map[string]interface{}{"host": "example.com", "port": "5432"},
+		}},
+		Connections: []reconcile.ConnectionSpec{{Source: "web", Destination: "warehouse"}},
+	}, spec)
+}
+
+func TestLoadJSON(t *testing.T) {
+	spec, err := reconcile.Load(strings.NewReader(`{
+		"sources": [{"name": "web", "type": "Javascript"}],
+		"destinations": [{"name": "hook", "type": "WEBHOOK", "config": {"webhookUrl": "https://example.com"}}],
+		"connections": [{"source": "web", "destination": "hook"}]
+	}`))
+	require.NoError(t, err)
+	assert.Len(t, spec.Sources, 1)
+	assert.Len(t, spec.Destinations, 1)
+	assert.Len(t, spec.Connections, 1)
+}
+
+func TestLoadInvalid(t *testing.T) {
+	_, err := reconcile.Load(strings.NewReader(`
+sources:
+  - name: web
+    type: Javascript
+  - name: web
+    type: HTTP
+`))
+	assert.EqualError(t, err, "duplicate source key 'web'")
+
+	_, err = reconcile.Load(strings.NewReader(`
+sources:
+  - name: web
+    type: Javascript
+connections:
+  - source: web
+    destination: warehouse
+`))
+	assert.EqualError(t, err, "connection 'web -> warehouse' references unknown destination 'warehouse'")
+
+	_, err = reconcile.Load(strings.NewReader(`
+sources:
+  - name: web
+    kind: Javascript
+`))
+	assert.Error(t, err)
+}
+
+func TestLoadMalformed(t *testing.T) {
+	assert.NotPanics(t, func() {
+		_, err := reconcile.Load(strings.NewReader("0: [:!00 \xef"))
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadEmpty(t *testing.T) {
+	for _, document := range []string{"", "  \n", "# no resources\n", "null", "{}"} {
+		_, err := reconcile.Load(strings.NewReader(document))
+		assert.Equal(t, reconcile.ErrEmptySpec, err, "%q", document)
+	}
+
+	spec, err := reconcile.Load(strings.NewReader("sources: []"))
+	require.NoError(t, err)
+	assert.Empty(t, spec.Sources)
+}
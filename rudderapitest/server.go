@@ -0,0 +1,458 @@
+// Package rudderapitest provides an in-memory fake of the Rudder API, for testing code built on the client package.
+package rudderapitest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+)
+
+// DefaultPageSize is the number of resources returned per page, unless set with WithPageSize.
+const DefaultPageSize = 50
+
+// Server is a stateful, in-memory fake of the Rudder API v2. It supports CRUD operations and paging
+// for sources, destinations and connections, and replies with API errors like the real API.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	accessToken string
+	pageSize    int
+
+	sources      *collection
+	destinations *collection
+	connections  *collection
+}
+
+type Option func(*Server)
+
+// WithPageSize sets the number of resources returned per page.
+func WithPageSize(pageSize int) Option {
+	return func(s *Server) {
+		s.pageSize = pageSize
+	}
+}
+
+// WithAccessToken makes the server reject requests which do not use the given access token.
+// By default, any access token is accepted.
+func WithAccessToken(accessToken string) Option {
+	return func(s *Server) {
+		s.accessToken = accessToken
+	}
+}
+
+// NewServer starts a new fake server. It must be closed by the caller.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		pageSize:     DefaultPageSize,
+		sources:      newCollection(),
+		destinations: newCollection(),
+		connections:  newCollection(),
+	}
+
+	for _, o := range options {
+		o(s)
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// BaseURL returns the base URL to configure clients with, using client.WithBaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + "/v2"
+}
+
+// Client returns a client configured to use the server.
+func (s *Server) Client(options ...client.Option) (*client.Client, error) {
+	accessToken := s.accessToken
+	if accessToken == "" {
+		accessToken = "rudderapitest-access-token"
+	}
+
+	return client.New(accessToken, append([]client.Option{client.WithBaseURL(s.BaseURL())}, options...)...)
+}
+
+// collection stores resources of one type, in creation order.
+type collection struct {
+	ids   []string
+	items map[string]interface{}
+}
+
+func newCollection() *collection {
+	return &collection{items: map[string]interface{}{}}
+}
+
+func (c *collection) add(id string, item interface{}) {
+	c.ids = append(c.ids, id)
+	c.items[id] = item
+}
+
+func (c *collection) remove(id string) {
+	delete(c.items, id)
+	for i := range c.ids {
+		if c.ids[i] == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			return
+		}
+	}
+}
+
+// page returns the items of the requested page, and the path of the next page, if any.
+func (c *collection) page(basePath string, page, pageSize int) ([]interface{}, string) {
+	start := (page - 1) * pageSize
+	if start > len(c.ids) {
+		start = len(c.ids)
+	}
+	end := start + pageSize
+	if end > len(c.ids) {
+		end = len(c.ids)
+	}
+
+	items := []interface{}{}
+	for _, id := range c.ids[start:end] {
+		items = append(items, c.items[id])
+	}
+
+	next := ""
+	if end < len(c.ids) {
+		next = fmt.Sprintf("/%s?page=%d", basePath, page+1)
+	}
+
+	return items, next
+}
+
+type apiError struct {
+	status  int
+	Message string      `json:"error"`
+	Code    string      `json:"code"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+func errNotFound(kind, id string) *apiError {
+	return &apiError{status: http.StatusNotFound, Message: fmt.Sprintf("%s '%s' not found", kind, id), Code: "not_found"}
+}
+
+func errValidation(message string, details interface{}) *apiError {
+	return &apiError{status: http.StatusBadRequest, Message: message, Code: "validation_error", Details: details}
+}
+
+func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
+	if s.accessToken != "" && req.Header.Get("Authorization") != "Bearer "+s.accessToken {
+		writeError(w, &apiError{status: http.StatusUnauthorized, Message: "invalid access token", Code: "unauthorized"})
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, "/v2"), "/"), "/")
+	if len(parts) > 2 {
+		writeError(w, &apiError{status: http.StatusNotFound, Message: "not found", Code: "not_found"})
+		return
+	}
+
+	id := ""
+	if len(parts) == 2 {
+		id = parts[1]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var status int
+	var body interface{}
+	var err *apiError
+
+	switch parts[0] {
+	case "sources":
+		status, body, err = s.handleSources(req, id)
+	case "destinations":
+		status, body, err = s.handleDestinations(req, id)
+	case "connections":
+		status, body, err = s.handleConnections(req, id)
+	default:
+		err = &apiError{status: http.StatusNotFound, Message: "not found", Code: "not_found"}
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, status, body)
+}
+
+// handleCollection implements the operations which are common to all collections.
+func (s *Server) handleCollection(req *http.Request, basePath, key string, c *collection, id string) (int, interface{}, *apiError, bool) {
+	switch {
+	case req.Method == "GET" && id == "":
+		page, err := strconv.Atoi(req.URL.Query().Get("page"))
+		if err != nil || page < 1 {
+			page = 1
+		}
+
+		items, next := c.page(basePath, page, s.pageSize)
+		return http.StatusOK, map[string]interface{}{
+			basePath: items,
+			"paging": client.Paging{Total: len(c.ids), Next: next},
+		}, nil, true
+
+	case req.Method == "GET":
+		item, ok := c.items[id]
+		if !ok {
+			return 0, nil, errNotFound(key, id), true
+		}
+		return http.StatusOK, map[string]interface{}{key: item}, nil, true
+	}
+
+	if id != "" {
+		if _, ok := c.items[id]; !ok {
+			return 0, nil, errNotFound(key, id), true
+		}
+	}
+
+	return 0, nil, nil, false
+}
+
+func (s *Server) handleSources(req *http.Request, id string) (int, interface{}, *apiError) {
+	if status, body, err, ok := s.handleCollection(req, "sources", "source", s.sources, id); ok {
+		return status, body, err
+	}
+
+	switch {
+	case req.Method == "POST" && id == "":
+		source := &client.Source{}
+		if err := decodeBody(req, source); err != nil {
+			return 0, nil, err
+		}
+		if err := validateNameAndType(source.Name, source.Type); err != nil {
+			return 0, nil, err
+		}
+
+		now := timestamp()
+		source.ID, source.WriteKey = newID(), newWriteKey()
+		source.Config = defaultConfig(source.Config)
+		source.CreatedAt, source.UpdatedAt = &now, &now
+		s.sources.add(source.ID, source)
+		return http.StatusCreated, map[string]interface{}{"source": source}, nil
+
+	case req.Method == "PUT" && id != "":
+		existing := s.sources.items[id].(*client.Source)
+		source := &client.Source{}
+		if err := decodeBody(req, source); err != nil {
+			return 0, nil, err
+		}
+		if err := validateNameAndType(source.Name, source.Type); err != nil {
+			return 0, nil, err
+		}
+
+		now := timestamp()
+		source.ID, source.WriteKey = existing.ID, existing.WriteKey
+		source.Config = defaultConfig(source.Config)
+		source.CreatedAt, source.UpdatedAt = existing.CreatedAt, &now
+		s.sources.items[id] = source
+		return http.StatusOK, map[string]interface{}{"source": source}, nil
+
+	case req.Method == "DELETE" && id != "":
+		s.sources.remove(id)
+		s.removeConnections(func(c *client.Connection) bool { return c.SourceID == id })
+		return http.StatusNoContent, nil, nil
+	}
+
+	return 0, nil, errMethodNotAllowed(req)
+}
+
+func (s *Server) handleDestinations(req *http.Request, id string) (int, interface{}, *apiError) {
+	if status, body, err, ok := s.handleCollection(req, "destinations", "destination", s.destinations, id); ok {
+		return status, body, err
+	}
+
+	switch {
+	case req.Method == "POST" && id == "":
+		destination := &client.Destination{}
+		if err := decodeBody(req, destination); err != nil {
+			return 0, nil, err
+		}
+		if err := validateNameAndType(destination.Name, destination.Type); err != nil {
+			return 0, nil, err
+		}
+
+		now := timestamp()
+		destination.ID = newID()
+		destination.Config = defaultConfig(destination.Config)
+		destination.CreatedAt, destination.UpdatedAt = &now, &now
+		s.destinations.add(destination.ID, destination)
+		return http.StatusCreated, map[string]interface{}{"destination": destination}, nil
+
+	case req.Method == "PUT" && id != "":
+		existing := s.destinations.items[id].(*client.Destination)
+		destination := &client.Destination{}
+		if err := decodeBody(req, destination); err != nil {
+			return 0, nil, err
+		}
+		if err := validateNameAndType(destination.Name, destination.Type); err != nil {
+			return 0, nil, err
+		}
+
+		now := timestamp()
+		destination.ID = existing.ID
+		destination.Config = defaultConfig(destination.Config)
+		destination.CreatedAt, destination.UpdatedAt = existing.CreatedAt, &now
+		s.destinations.items[id] = destination
+		return http.StatusOK, map[string]interface{}{"destination": destination}, nil
+
+	case req.Method == "DELETE" && id != "":
+		s.destinations.remove(id)
+		s.removeConnections(func(c *client.Connection) bool { return c.DestinationID == id })
+		return http.StatusNoContent, nil, nil
+	}
+
+	return 0, nil, errMethodNotAllowed(req)
+}
+
+func (s *Server) handleConnections(req *http.Request, id string) (int, interface{}, *apiError) {
+	if status, body, err, ok := s.handleCollection(req, "connections", "connection", s.connections, id); ok {
+		return status, body, err
+	}
+
+	switch {
+	case req.Method == "POST" && id == "":
+		connection := &client.Connection{}
+		if err := decodeBody(req, connection); err != nil {
+			return 0, nil, err
+		}
+		if err := s.validateConnection(connection, ""); err != nil {
+			return 0, nil, err
+		}
+
+		now := timestamp()
+		connection.ID = newID()
+		connection.CreatedAt, connection.UpdatedAt = &now, &now
+		s.connections.add(connection.ID, connection)
+		return http.StatusCreated, map[string]interface{}{"connection": connection}, nil
+
+	case req.Method == "PUT" && id != "":
+		existing := s.connections.items[id].(*client.Connection)
+		connection := &client.Connection{}
+		if err := decodeBody(req, connection); err != nil {
+			return 0, nil, err
+		}
+		if err := s.validateConnection(connection, id); err != nil {
+			return 0, nil, err
+		}
+
+		now := timestamp()
+		connection.ID = existing.ID
+		connection.CreatedAt, connection.UpdatedAt = existing.CreatedAt, &now
+		s.connections.items[id] = connection
+		return http.StatusOK, map[string]interface{}{"connection": connection}, nil
+
+	case req.Method == "DELETE" && id != "":
+		s.connections.remove(id)
+		return http.StatusNoContent, nil, nil
+	}
+
+	return 0, nil, errMethodNotAllowed(req)
+}
+
+func (s *Server) validateConnection(connection *client.Connection, id string) *apiError {
+	if _, ok := s.sources.items[connection.SourceID]; !ok {
+		return errValidation("invalid connection", []map[string]string{{"field": "sourceId", "message": "source does not exist"}})
+	}
+	if _, ok := s.destinations.items[connection.DestinationID]; !ok {
+		return errValidation("invalid connection", []map[string]string{{"field": "destinationId", "message": "destination does not exist"}})
+	}
+
+	for _, existingID := range s.connections.ids {
+		existing := s.connections.items[existingID].(*client.Connection)
+		if existingID != id && existing.SourceID == connection.SourceID && existing.DestinationID == connection.DestinationID {
+			return &apiError{status: http.StatusConflict, Message: "connection already exists", Code: "conflict"}
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) removeConnections(match func(*client.Connection) bool) {
+	for _, id := range append([]string{}, s.connections.ids...) {
+		if match(s.connections.items[id].(*client.Connection)) {
+			s.connections.remove(id)
+		}
+	}
+}
+
+func validateNameAndType(name, resourceType string) *apiError {
+	var details []map[string]string
+	if name == "" {
+		details = append(details, map[string]string{"field": "name", "message": "name is required"})
+	}
+	if resourceType == "" {
+		details = append(details, map[string]string{"field": "type", "message": "type is required"})
+	}
+
+	if details != nil {
+		return errValidation("invalid request body", details)
+	}
+
+	return nil
+}
+
+func errMethodNotAllowed(req *http.Request) *apiError {
+	return &apiError{status: http.StatusMethodNotAllowed, Message: fmt.Sprintf("method %s not allowed", req.Method), Code: "method_not_allowed"}
+}
+
+func decodeBody(req *http.Request, v interface{}) *apiError {
+	if err := json.NewDecoder(req.Body).Decode(v); err != nil {
+		return &apiError{status: http.StatusBadRequest, Message: fmt.Sprintf("invalid request body: %v", err), Code: "invalid_request"}
+	}
+	return nil
+}
+
+func defaultConfig(config json.RawMessage) json.RawMessage {
+	if len(config) == 0 || string(config) == "null" {
+		return json.RawMessage("{}")
+	}
+	return config
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	writeJSON(w, err.status, err)
+}
+
+func timestamp() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+func newID() string {
+	return randomHex(14)
+}
+
+func newWriteKey() string {
+	return randomHex(16)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
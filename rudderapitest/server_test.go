@@ -0,0 +1,147 @@
+package rudderapitest_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rudderlabs/rudder-api-go/client"
+	"github.com/rudderlabs/rudder-api-go/rudderapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerCRUD(t *testing.T) {
+	ctx := context.Background()
+	server := rudderapitest.NewServer()
+	defer server.Close()
+
+	c, err := client.New("some-access-token", client.WithBaseURL(server.BaseURL()))
+	require.NoError(t, err)
+
+	source, err := c.Sources.Create(ctx, &client.Source{Name: "some-source", Type: "HTTP", IsEnabled: true})
+	require.NoError(t, err)
+	assert.NotEmpty(t, source.ID)
+	assert.Len(t, source.WriteKey, 32)
+	assert.Equal(t, json.RawMessage(`{}`), source.Config)
+	assert.NotNil(t, source.CreatedAt)
+
+	destination, err := c.Destinations.Create(ctx, &client.Destination{Name: "some-destination", Type: "WEBHOOK", Config: json.RawMessage(`{"webhookUrl":"https://example.com"}`)})
+	require.NoError(t, err)
+	assert.NotEmpty(t, destination.ID)
+
+	connection, err := c.Connections.Create(ctx, &client.Connection{SourceID: source.ID, DestinationID: destination.ID, IsEnabled: true})
+	require.NoError(t, err)
+	assert.NotEmpty(t, connection.ID)
+
+	fetched, err := c.Sources.Get(ctx, source.ID)
+	require.NoError(t, err)
+	assert.Equal(t, source, fetched)
+
+	source.Name = "other-name"
+	updated, err := c.Sources.Update(ctx, source)
+	require.NoError(t, err)
+	assert.Equal(t, "other-name", updated.Name)
+	assert.Equal(t, source.WriteKey, updated.WriteKey)
+
+	fetchedDestination, err := c.Destinations.Get(ctx, destination.ID)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"webhookUrl":"https://example.com"}`, string(fetchedDestination.Config))
+
+	// deleting a source also deletes its connections
+	require.NoError(t, c.Sources.Delete(ctx, source.ID))
+
+	_, err = c.Sources.Get(ctx, source.ID)
+	apiErr, ok := err.(*client.APIError)
+	require.True(t, ok)
+	assert.Equal(t, 404, apiErr.HTTPStatusCode)
+	assert.Equal(t, "not_found", apiErr.ErrorCode)
+
+	_, err = c.Connections.Get(ctx, connection.ID)
+	assert.Error(t, err)
+}
+
+func TestServerPaging(t *testing.T) {
+	ctx := context.Background()
+	server := rudderapitest.NewServer(rudderapitest.WithPageSize(2))
+	defer server.Close()
+
+	c, err := server.Client()
+	require.NoError(t, err)
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		_, err := c.Destinations.Create(ctx, &client.Destination{Name: name, Type: "WEBHOOK"})
+		require.NoError(t, err)
+	}
+
+	page, err := c.Destinations.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, page.Destinations, 2)
+	assert.Equal(t, 5, page.Paging.Total)
+	assert.Equal(t, "/destinations?page=2", page.Paging.Next)
+
+	var names []string
+	it := c.Destinations.All(ctx)
+	for it.Next() {
+		names = append(names, it.Destination().Name)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, names)
+}
+
+func TestServerErrors(t *testing.T) {
+	ctx := context.Background()
+	server := rudderapitest.NewServer()
+	defer server.Close()
+
+	c, err := server.Client()
+	require.NoError(t, err)
+
+	_, err = c.Sources.Create(ctx, &client.Source{})
+	apiErr, ok := err.(*client.APIError)
+	require.True(t, ok)
+	assert.Equal(t, 400, apiErr.HTTPStatusCode)
+	assert.Equal(t, "validation_error", apiErr.ErrorCode)
+	assert.JSONEq(t, `[{"field": "name", "message": "name is required"}, {"field": "type", "message": "type is required"}]`, string(apiErr.Details))
+
+	_, err = c.Connections.Create(ctx, &client.Connection{SourceID: "unknown", DestinationID: "unknown"})
+	apiErr, ok = err.(*client.APIError)
+	require.True(t, ok)
+	assert.Equal(t, 400, apiErr.HTTPStatusCode)
+
+	source, err := c.Sources.Create(ctx, &client.Source{Name: "source", Type: "HTTP"})
+	require.NoError(t, err)
+	destination, err := c.Destinations.Create(ctx, &client.Destination{Name: "destination", Type: "WEBHOOK"})
+	require.NoError(t, err)
+	_, err = c.Connections.Create(ctx, &client.Connection{SourceID: source.ID, DestinationID: destination.ID})
+	require.NoError(t, err)
+
+	_, err = c.Connections.Create(ctx, &client.Connection{SourceID: source.ID, DestinationID: destination.ID})
+	apiErr, ok = err.(*client.APIError)
+	require.True(t, ok)
+	assert.Equal(t, 409, apiErr.HTTPStatusCode)
+
+	err = c.Destinations.Delete(ctx, "unknown")
+	apiErr, ok = err.(*client.APIError)
+	require.True(t, ok)
+	assert.Equal(t, 404, apiErr.HTTPStatusCode)
+}
+
+func TestServerAccessToken(t *testing.T) {
+	server := rudderapitest.NewServer(rudderapitest.WithAccessToken("valid-token"))
+	defer server.Close()
+
+	c, err := client.New("invalid-token", client.WithBaseURL(server.BaseURL()))
+	require.NoError(t, err)
+
+	_, err = c.Sources.List(context.Background())
+	apiErr, ok := err.(*client.APIError)
+	require.True(t, ok)
+	assert.Equal(t, 401, apiErr.HTTPStatusCode)
+
+	c, err = server.Client()
+	require.NoError(t, err)
+
+	_, err = c.Sources.List(context.Background())
+	require.NoError(t, err)
+}